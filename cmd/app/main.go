@@ -4,14 +4,18 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/go-chi/chi"
-	"github.com/notblinkyet/song-library-api/internal/config"
+	cfg "github.com/notblinkyet/song-library-api/internal/config"
+	"github.com/notblinkyet/song-library-api/internal/database"
+	"github.com/notblinkyet/song-library-api/internal/database/memory"
 	"github.com/notblinkyet/song-library-api/internal/database/postgresql"
 	"github.com/notblinkyet/song-library-api/internal/lib/api"
 	"github.com/notblinkyet/song-library-api/internal/lib/sl"
@@ -29,7 +33,7 @@ import (
 
 func main() {
 	// Load configuration
-	config := config.MustLoadConfig()
+	config := cfg.MustLoadConfig()
 	log := logger.SetupLogger()
 
 	log.Info("Configuration loaded", slog.Any("config", config))
@@ -40,22 +44,88 @@ func main() {
 
 	// Initialize dependencies
 	log.Info("Initializing dependencies")
-	db, err := postgresql.NewPostgreSQL(config)
-	if err != nil {
-		log.Error("Failed to connect to database", sl.Error(err))
-		os.Exit(1)
+	var (
+		db      database.Storage
+		closeDB func()
+		store   api.CacheStore
+	)
+	switch config.Storage {
+	case cfg.StorageMemory:
+		mem := memory.NewMemory()
+		db, closeDB = mem, mem.Close
+		log.Info("Using in-memory storage")
+	default:
+		pg, err := postgresql.NewPostgreSQL(config)
+		if err != nil {
+			log.Error("Failed to connect to database", sl.Error(err))
+			os.Exit(1)
+		}
+		db, closeDB = pg, pg.Close
+		log.Info("Database connection established")
+		if config.ApiCachePersist {
+			store = pg
+		}
 	}
-	log.Info("Database connection established")
 
-	apiClient := api.NewApiClient(config.ApiAddrURL)
-	server := services.NewSongLibraryService(db, apiClient, log)
-	handler := myHttp.NewHandler(server, log)
+	// Calls to the song info API, retries included, end before the server
+	// stops writing the response, leaving time to store the song.
+	apiDeadline := config.Timeout * 4 / 5
+
+	providers := make([]api.Provider, 0, len(config.ApiProviders))
+	for _, p := range config.ApiProviders {
+		providers = append(providers, api.Provider{
+			Name: p.Name,
+			Client: api.NewApiClient(p.AddrURL, &http.Client{}, api.Options{
+				Timeout:          config.ApiTimeout,
+				Deadline:         apiDeadline,
+				MaxRetries:       config.ApiRetries,
+				BackoffBase:      config.ApiBackoffBase,
+				BackoffMax:       config.ApiBackoffMax,
+				BreakerThreshold: config.ApiBreakerThreshold,
+				BreakerCooldown:  config.ApiBreakerCooldown,
+				Request: api.RequestTemplate{
+					Path:         p.InfoPath,
+					TitleParam:   p.TitleParam,
+					GroupParam:   p.GroupParam,
+					Headers:      p.Headers,
+					APIKey:       p.Key,
+					APIKeyHeader: p.KeyHeader,
+					APIKeyParam:  p.KeyParam,
+				},
+				Mapping: api.ResponseMapping{
+					ReleaseDate:       p.MapReleaseDate,
+					Text:              p.MapText,
+					Link:              p.MapLink,
+					ReleaseDateLayout: p.ReleaseDateLayout,
+				},
+			}),
+		})
+	}
+	apiClient := api.NewComposite(providers, config.ApiProvidersMode == cfg.ProvidersParallel, apiDeadline)
+
+	var client services.ApiClient = apiClient
+	if config.ApiCacheSize > 0 && config.ApiCacheTTL > 0 {
+		client = api.NewCache(apiClient, log, api.CacheOptions{
+			TTL:         config.ApiCacheTTL,
+			NegativeTTL: config.ApiCacheNegativeTTL,
+			MaxEntries:  config.ApiCacheSize,
+			Store:       store,
+		})
+		log.Info("Caching song info", slog.Bool("persistent", store != nil))
+	}
+	server := services.NewSongLibraryService(db, client, log)
+	handler := myHttp.NewHandler(server, log, config.EnrichMode == cfg.EnrichAsync)
 
 	// Set up HTTP router and endpoints
 	r := chi.NewMux()
 	handler.FillEndpoints(r)
 	log.Debug("HTTP routes configured")
 
+	// Requests derive their context from baseCtx, so cancelling it on shutdown
+	// aborts the queries of requests that are still in flight.
+	baseCtx, cancelBase := context.WithCancel(context.Background())
+	defer cancelBase()
+
 	// Create HTTP server
 	srv := &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", config.ServerHost, config.ServerPort),
@@ -63,13 +133,57 @@ func main() {
 		ReadTimeout:  config.Timeout,
 		WriteTimeout: config.Timeout,
 		IdleTimeout:  config.IdleTimeout,
+		BaseContext: func(net.Listener) context.Context {
+			return baseCtx
+		},
 	}
 	log.Info("HTTP server configured", slog.String("address", srv.Addr))
 
+	// Background workers stop when baseCtx is cancelled and must be done
+	// before the storage is closed.
+	var workers sync.WaitGroup
+
+	// Permanently remove songs that stayed in the trash for too long
+	if config.TrashPurgeInterval > 0 {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			server.RunTrashPurge(baseCtx, config.TrashPurgeInterval, config.TrashRetention)
+		}()
+	}
+
+	// Fetch the details of songs created asynchronously
+	if config.EnrichMode == cfg.EnrichAsync {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			server.RunEnrichment(baseCtx, services.EnrichmentOptions{
+				Workers:      config.EnrichWorkers,
+				MaxAttempts:  config.EnrichMaxAttempts,
+				RetryDelay:   config.EnrichRetryDelay,
+				PollInterval: config.EnrichPollInterval,
+			})
+		}()
+	}
+
+	// Fetch the details of stale songs again
+	if config.RefreshInterval > 0 {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			server.RunRefresh(baseCtx, services.RefreshOptions{
+				Interval: config.RefreshInterval,
+				MaxAge:   config.RefreshMaxAge,
+				EmptyAge: config.RefreshEmptyAge,
+				Batch:    config.RefreshBatch,
+			})
+		}()
+	}
+
 	// Run server in a separate goroutine
 	go func() {
 		log.Info("Starting server", slog.String("address", srv.Addr))
-		if err = srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Error("Failed to start server", sl.Error(err))
 			done <- syscall.SIGTERM
 		}
@@ -84,13 +198,16 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	if err = srv.Shutdown(ctx); err != nil {
+	if err := srv.Shutdown(ctx); err != nil {
 		log.Error("Failed to shut down server", sl.Error(err))
 	} else {
 		log.Info("Server stopped gracefully")
 	}
+	cancelBase()
+	workers.Wait()
+	log.Info("Background workers stopped")
 
 	// Close database connection
-	db.Close()
-	log.Info("Database connection closed")
+	closeDB()
+	log.Info("Storage closed")
 }
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/notblinkyet/song-library-api/internal/database/memory"
+	"github.com/notblinkyet/song-library-api/internal/lib/api"
+	"github.com/notblinkyet/song-library-api/internal/models"
+	"github.com/notblinkyet/song-library-api/internal/services"
+	myHttp "github.com/notblinkyet/song-library-api/internal/transport/http"
+)
+
+// startApp runs the mock API with the fixtures of this directory and the
+// application with in-memory storage in front of it, and returns the URL of
+// the application.
+func startApp(t *testing.T, opts Options) string {
+	t.Helper()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	fixtures, err := loadFixtures("fixtures")
+	if err != nil {
+		t.Fatalf("failed to load fixtures: %v", err)
+	}
+	mock := chi.NewMux()
+	mock.Get("/info", newServer(fixtures, log, opts).Info)
+	mockSrv := httptest.NewServer(mock)
+	t.Cleanup(mockSrv.Close)
+
+	client := api.NewApiClient(mockSrv.URL, mockSrv.Client(), api.Options{
+		Timeout:     time.Second,
+		MaxRetries:  2,
+		BackoffBase: time.Millisecond,
+		BackoffMax:  10 * time.Millisecond,
+	})
+	db := memory.NewMemory()
+	t.Cleanup(db.Close)
+	service := services.NewSongLibraryService(db, api.NewComposite([]api.Provider{{Name: "mock", Client: client}}, false, 0), log)
+
+	r := chi.NewMux()
+	myHttp.NewHandler(service, log, false).FillEndpoints(r)
+	appSrv := httptest.NewServer(r)
+	t.Cleanup(appSrv.Close)
+	return appSrv.URL
+}
+
+// createSong posts the song to the application and returns the response.
+func createSong(t *testing.T, url, song, group string) *http.Response {
+	t.Helper()
+	body := fmt.Sprintf(`{"song": %q, "group": %q}`, song, group)
+	resp, err := http.Post(url+"/songs", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST /songs: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestCreateSongFromMock(t *testing.T) {
+	tests := []struct {
+		name       string
+		song       string
+		group      string
+		opts       Options
+		wantStatus int
+	}{
+		{name: "known song", song: "Supermassive Black Hole", group: "Muse", wantStatus: http.StatusCreated},
+		{name: "case and spaces ignored", song: " bohemian  rhapsody", group: "QUEEN", wantStatus: http.StatusCreated},
+		{name: "unknown song", song: "Unknown", group: "Nobody", wantStatus: http.StatusBadRequest},
+		{name: "fixture error", song: "Broken Song", group: "Mock", wantStatus: http.StatusBadGateway},
+		{
+			name: "retried after injected errors", song: "Supermassive Black Hole", group: "Muse",
+			opts:       Options{FailFirst: 2, ErrorStatus: http.StatusServiceUnavailable},
+			wantStatus: http.StatusCreated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := startApp(t, tt.opts)
+			resp := createSong(t, url, tt.song, tt.group)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestReadVersesFromMock(t *testing.T) {
+	url := startApp(t, Options{})
+
+	resp := createSong(t, url, "Supermassive Black Hole", "Muse")
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("create status = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+	var id models.Id
+	if err := json.NewDecoder(resp.Body).Decode(&id); err != nil {
+		t.Fatalf("failed to decode song ID: %v", err)
+	}
+
+	resp, err := http.Get(fmt.Sprintf("%s/songs/%d?start=2", url, id.Id))
+	if err != nil {
+		t.Fatalf("GET /songs/%d: %v", id.Id, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("read status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var verses []models.Verse
+	if err = json.NewDecoder(resp.Body).Decode(&verses); err != nil {
+		t.Fatalf("failed to decode verses: %v", err)
+	}
+	want := "Ooh\nYou set my soul alight\nOoh\nYou set my soul alight"
+	if len(verses) != 1 || verses[0].Verse != want {
+		t.Errorf("verses = %+v, want one verse %q", verses, want)
+	}
+}
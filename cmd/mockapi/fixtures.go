@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/lib/api"
+	"gopkg.in/yaml.v2"
+)
+
+// Fixture is the answer of the mock API for one song. Status and Delay
+// override the injected errors and latency for this song.
+type Fixture struct {
+	Song        string `json:"song" yaml:"song"`
+	Group       string `json:"group" yaml:"group"`
+	ReleaseDate string `json:"releaseDate" yaml:"releaseDate"`
+	Text        string `json:"text" yaml:"text"`
+	Link        string `json:"link" yaml:"link"`
+	Status      int    `json:"status,omitempty" yaml:"status,omitempty"`
+	Delay       string `json:"delay,omitempty" yaml:"delay,omitempty"`
+
+	delay time.Duration
+}
+
+// SongDetail is the body of a successful response, as described by the
+// contract of the song info API.
+type SongDetail struct {
+	ReleaseDate string `json:"releaseDate"`
+	Text        string `json:"text"`
+	Link        string `json:"link"`
+}
+
+// loadFixtures reads every .json, .yaml and .yml file of the directory. A
+// file holds either a single fixture or a list of them. Fixtures are keyed
+// like the cache of the API client, so lookups ignore case and extra spaces.
+func loadFixtures(dir string) (map[string]*Fixture, error) {
+	const op = "mockapi.loadFixtures"
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	fixtures := make(map[string]*Fixture)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		var list []*Fixture
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".json":
+			list, err = decodeFixtures(path, json.Unmarshal)
+		case ".yaml", ".yml":
+			list, err = decodeFixtures(path, yaml.Unmarshal)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		for i, f := range list {
+			if strings.TrimSpace(f.Song) == "" || strings.TrimSpace(f.Group) == "" {
+				return nil, fmt.Errorf("%s: %s: fixture %d: song and group are required", op, path, i+1)
+			}
+			if f.Delay != "" {
+				if f.delay, err = time.ParseDuration(f.Delay); err != nil {
+					return nil, fmt.Errorf("%s: %s: fixture %d: %w", op, path, i+1, err)
+				}
+			}
+			key := api.CacheKey(f.Song, f.Group)
+			if _, ok := fixtures[key]; ok {
+				return nil, fmt.Errorf("%s: %s: duplicate fixture for %q by %q", op, path, f.Song, f.Group)
+			}
+			fixtures[key] = f
+		}
+	}
+	return fixtures, nil
+}
+
+// decodeFixtures reads a file holding a list of fixtures or a single one.
+func decodeFixtures(path string, unmarshal func([]byte, any) error) ([]*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*Fixture
+	if err = unmarshal(data, &list); err == nil {
+		return list, nil
+	}
+	var single Fixture
+	if err = unmarshal(data, &single); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return []*Fixture{&single}, nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+	"github.com/notblinkyet/song-library-api/internal/logger"
+)
+
+// mockapi serves the song info API from fixtures, so that the application
+// can be run and tested without the external service.
+func main() {
+	// Define flags
+	var (
+		addr     string
+		fixtures string
+		opts     Options
+	)
+	flag.StringVar(&addr, "addr", ":8081", "address to listen on")
+	flag.StringVar(&fixtures, "fixtures", "cmd/mockapi/fixtures", "directory with JSON and YAML fixtures")
+	flag.DurationVar(&opts.Latency, "latency", 0, "delay before every response")
+	flag.DurationVar(&opts.Jitter, "jitter", 0, "random delay of up to this value added to latency")
+	flag.Float64Var(&opts.ErrorRate, "error-rate", 0, "share of requests answered with an error, from 0 to 1")
+	flag.IntVar(&opts.ErrorStatus, "error-status", http.StatusInternalServerError, "status of injected errors")
+	flag.IntVar(&opts.FailFirst, "fail-first", 0, "number of first requests answered with an error")
+	flag.DurationVar(&opts.RetryAfter, "retry-after", 0, "Retry-After sent with injected errors, 0 omits it")
+	flag.Parse()
+
+	// Set up logging
+	log := logger.SetupLogger()
+
+	if opts.ErrorRate < 0 || opts.ErrorRate > 1 {
+		log.Error("Error rate must be between 0 and 1", slog.Float64("errorRate", opts.ErrorRate))
+		os.Exit(1)
+	}
+	if opts.ErrorStatus < 100 || opts.ErrorStatus > 599 {
+		log.Error("Error status is not a valid HTTP status", slog.Int("errorStatus", opts.ErrorStatus))
+		os.Exit(1)
+	}
+
+	// Load fixtures
+	songs, err := loadFixtures(fixtures)
+	if err != nil {
+		log.Error("Failed to load fixtures", sl.Error(err))
+		os.Exit(1)
+	}
+	log.Info("Fixtures loaded", slog.String("dir", fixtures), slog.Int("songs", len(songs)))
+
+	// Set up signal handling for graceful shutdown
+	done := make(chan os.Signal, 1)
+	signal.Notify(done, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
+
+	r := chi.NewMux()
+	r.Get("/info", newServer(songs, log, opts).Info)
+
+	srv := &http.Server{
+		Addr:    addr,
+		Handler: r,
+	}
+
+	go func() {
+		log.Info("Starting mock API", slog.String("address", srv.Addr),
+			slog.Duration("latency", opts.Latency), slog.Float64("errorRate", opts.ErrorRate),
+			slog.Int("failFirst", opts.FailFirst))
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Error("Failed to start mock API", sl.Error(err))
+			done <- syscall.SIGTERM
+		}
+	}()
+
+	// Wait for shutdown signal
+	<-done
+	log.Info("Shutdown signal received, stopping mock API")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Error("Failed to shut down mock API", sl.Error(err))
+	} else {
+		log.Info("Mock API stopped gracefully")
+	}
+}
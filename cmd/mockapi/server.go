@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/lib/api"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+)
+
+// Options configure the latency and the errors injected by the mock API.
+type Options struct {
+	Latency     time.Duration // Delay before every response.
+	Jitter      time.Duration // Random delay of up to Jitter added to Latency.
+	ErrorRate   float64       // Share of requests answered with ErrorStatus, from 0 to 1.
+	ErrorStatus int           // Status of injected errors.
+	FailFirst   int           // Number of first requests answered with ErrorStatus.
+	RetryAfter  time.Duration // Retry-After sent with injected errors; 0 omits it.
+}
+
+// server answers GET /info?song=&group= like the song info API.
+type server struct {
+	fixtures map[string]*Fixture
+	log      *slog.Logger
+	opts     Options
+
+	mu       sync.Mutex
+	requests int
+}
+
+func newServer(fixtures map[string]*Fixture, log *slog.Logger, opts Options) *server {
+	return &server{
+		fixtures: fixtures,
+		log:      log,
+		opts:     opts,
+	}
+}
+
+// Info returns the details of the song from the fixtures. Unknown songs and
+// requests without song or group are answered with 400, as the real API does.
+func (s *server) Info(w http.ResponseWriter, r *http.Request) {
+	song, group := r.URL.Query().Get("song"), r.URL.Query().Get("group")
+	log := s.log.With(slog.String("song", song), slog.String("group", group))
+
+	// Find the fixture of the song, which requires both the song and the group.
+	if song == "" || group == "" {
+		log.Info("song or group is missing")
+		http.Error(w, "song and group are required", http.StatusBadRequest)
+		return
+	}
+	fixture := s.fixtures[api.CacheKey(song, group)]
+
+	// Wait for the latency of the fixture, giving up if the client has gone.
+	select {
+	case <-time.After(s.delay(fixture)):
+	case <-r.Context().Done():
+		log.Info("request cancelled by client")
+		return
+	}
+
+	// Answer with an injected error if it's this request's turn to fail.
+	if s.fail() {
+		log.Info("injecting error", slog.Int("status", s.opts.ErrorStatus))
+		if s.opts.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(s.opts.RetryAfter.Seconds())))
+		}
+		http.Error(w, http.StatusText(s.opts.ErrorStatus), s.opts.ErrorStatus)
+		return
+	}
+
+	// Answer with the details of the song, or with the status of its fixture.
+	if fixture == nil {
+		log.Info("song not found")
+		http.Error(w, "song not found", http.StatusBadRequest)
+		return
+	}
+	if fixture.Status != 0 && fixture.Status != http.StatusOK {
+		log.Info("answering with fixture status", slog.Int("status", fixture.Status))
+		http.Error(w, http.StatusText(fixture.Status), fixture.Status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(SongDetail{
+		ReleaseDate: fixture.ReleaseDate,
+		Text:        fixture.Text,
+		Link:        fixture.Link,
+	})
+	if err != nil {
+		log.Warn("failed to write response", sl.Error(err))
+		return
+	}
+	log.Info("song found")
+}
+
+// delay returns the delay of the fixture if it has one, or the configured
+// latency with jitter.
+func (s *server) delay(fixture *Fixture) time.Duration {
+	if fixture != nil && fixture.delay > 0 {
+		return fixture.delay
+	}
+	d := s.opts.Latency
+	if s.opts.Jitter > 0 {
+		d += rand.N(s.opts.Jitter)
+	}
+	return d
+}
+
+// fail reports whether the request is to be answered with an injected error.
+func (s *server) fail() bool {
+	s.mu.Lock()
+	s.requests++
+	n := s.requests
+	s.mu.Unlock()
+
+	if n <= s.opts.FailFirst {
+		return true
+	}
+	return s.opts.ErrorRate > 0 && rand.Float64() < s.opts.ErrorRate
+}
@@ -15,77 +15,36 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/songs": {
+        "/groups": {
             "get": {
-                "description": "Retrieves a list of songs from the library based on optional filters.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieves all groups together with the number of songs in each of them.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "songs"
-                ],
-                "summary": "Retrieve songs based on filters",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Song title",
-                        "name": "song",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Song group",
-                        "name": "group",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Song release date YYYY.MM.DD",
-                        "name": "release_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Text search in song details",
-                        "name": "text",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Link search in song details",
-                        "name": "link",
-                        "in": "query"
-                    }
+                    "groups"
                 ],
+                "summary": "List groups",
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved songs",
+                        "description": "Successfully retrieved groups",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Song"
+                                "$ref": "#/definitions/models.Group"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Invalid request (e.g., invalid filter parameters)",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             },
             "post": {
-                "description": "Creates a new song in the library. Requires a valid JSON request body containing the song's details.",
+                "description": "Creates a new group in the library. Group names are unique.",
                 "consumes": [
                     "application/json"
                 ],
@@ -93,116 +52,107 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "songs"
+                    "groups"
                 ],
-                "summary": "Create a new song",
+                "summary": "Create a new group",
                 "parameters": [
                     {
-                        "description": "Song details",
-                        "name": "song",
+                        "description": "Group details",
+                        "name": "group",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateSongRequest"
+                            "$ref": "#/definitions/models.GroupRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Successfully created song",
+                        "description": "Successfully created group",
                         "schema": {
                             "$ref": "#/definitions/models.Id"
                         }
                     },
                     "400": {
-                        "description": "Invalid request (e.g., missing required fields)",
+                        "description": "Invalid request (e.g., missing name)",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Group with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/songs/{id}": {
+        "/groups/{id}": {
             "get": {
-                "description": "Retrieves the verses of a song by its ID.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieves a group and the number of its songs by the group ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "songs"
+                    "groups"
                 ],
-                "summary": "Retrieve verses of a song by ID",
+                "summary": "Retrieve a group by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Song ID",
+                        "description": "Group ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Start index for verse retrieval (1-based index). Defaults to 1.",
-                        "name": "start",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of verses to retrieve. Defaults to 1.",
-                        "name": "count",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Verses of the song",
+                        "description": "Successfully retrieved group",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Verse"
-                            }
+                            "$ref": "#/definitions/models.Group"
                         }
                     },
                     "400": {
-                        "description": "Invalid request parameters or song does not contain requested verses.",
+                        "description": "Invalid group ID",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "404": {
-                        "description": "Song not found.",
+                        "description": "Group not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             },
             "delete": {
-                "description": "Deletes a song from the library by its ID.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Deletes a group from the library. Only groups without songs can be deleted.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "songs"
+                    "groups"
                 ],
-                "summary": "Delete a song by ID",
+                "summary": "Delete a group by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Song ID",
+                        "description": "Group ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -213,21 +163,33 @@ const docTemplate = `{
                         "description": "OK"
                     },
                     "400": {
-                        "description": "Invalid song ID",
+                        "description": "Invalid group ID",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Group still has songs",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error during deletion",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             },
             "patch": {
-                "description": "Updates an existing song in the library by its ID. Only the specified fields in the request body will be updated. The request body must contain a valid JSON representation of the ` + "`" + `models.Song` + "`" + ` struct.",
+                "description": "Renames an existing group. All songs of the group keep referring to it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -235,102 +197,2497 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "songs"
+                    "groups"
                 ],
-                "summary": "Update a song by ID",
+                "summary": "Rename a group by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Song ID",
+                        "description": "Group ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated song information. Only fields with non-empty values will be updated.",
-                        "name": "song",
+                        "description": "New group name",
+                        "name": "group",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Song"
+                            "$ref": "#/definitions/models.GroupRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated song",
+                        "description": "Successfully renamed group",
                         "schema": {
-                            "$ref": "#/definitions/models.Song"
+                            "$ref": "#/definitions/models.Group"
                         }
                     },
                     "400": {
-                        "description": "Invalid request (e.g., invalid JSON or missing required fields).",
+                        "description": "Invalid request (e.g., invalid JSON or missing name)",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "404": {
-                        "description": "Song not found.",
+                        "description": "Group not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Group with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "500": {
-                        "description": "Internal server error during the update process.",
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.CreateSongRequest": {
-            "type": "object",
-            "properties": {
-                "group": {
-                    "type": "string"
-                },
-                "song": {
-                    "type": "string"
-                }
-            }
         },
-        "models.Id": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "integer"
+        "/groups/{id}/songs": {
+            "get": {
+                "description": "Retrieves the songs of a group by the group ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Retrieve songs of a group",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of songs to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of songs to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved songs",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Song"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid group ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
                 }
             }
         },
-        "models.Song": {
-            "type": "object",
-            "properties": {
-                "group": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "link": {
-                    "type": "string"
-                },
-                "releaseDate": {
-                    "type": "string"
-                },
-                "song": {
-                    "type": "string"
-                },
-                "text": {
-                    "type": "string"
+        "/jobs/{id}": {
+            "get": {
+                "description": "Retrieves the status of the job that fetches the details of a song created asynchronously.\nA failed attempt is retried at nextRunAt; after the last attempt the job and its song get the failed status.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Retrieve an enrichment job by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved job",
+                        "schema": {
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid job ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
                 }
             }
         },
-        "models.Verse": {
-            "type": "object",
-            "properties": {
+        "/songs": {
+            "get": {
+                "description": "Retrieves a page of songs from the library based on optional filters.\nPages can be requested either by offset or by the opaque cursor returned in next_cursor.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Retrieve songs based on filters",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Song title; repeat the parameter to match any of several titles",
+                        "name": "song",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "exact",
+                            "prefix",
+                            "contains"
+                        ],
+                        "type": "string",
+                        "description": "How song is matched: exact (default), prefix or contains; prefix and contains ignore case",
+                        "name": "song_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Song group; repeat the parameter to match any of several groups",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "exact",
+                            "prefix",
+                            "contains"
+                        ],
+                        "type": "string",
+                        "description": "How group is matched: exact (default), prefix or contains; prefix and contains ignore case",
+                        "name": "group_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Song release date DD.MM.YYYY",
+                        "name": "release_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest release date DD.MM.YYYY, inclusive",
+                        "name": "release_date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest release date DD.MM.YYYY, inclusive",
+                        "name": "release_date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Release year",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive substring search in song text",
+                        "name": "text",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Full-text search in song titles and lyrics (websearch syntax, Russian and English). Results are ordered by relevance and include rank and snippet.",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link search in song details",
+                        "name": "link",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of songs on the page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of songs to skip (offset pagination)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort keys: id, title (or song), group, release_date; prefix a key with - for descending order, e.g. -release_date,title",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from next_cursor of the previous page (cursor pagination)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the total number of matching songs",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved songs",
+                        "schema": {
+                            "$ref": "#/definitions/models.SongPage"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "RFC 8288 links to the first, next and previous pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request (e.g., invalid filter parameters)",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new song in the library. Requires a valid JSON request body containing the song's details.\nThe details of the song are fetched from the song info API. When the server runs in async mode, the song is stored right away\nwith the pending status and 202 is returned with the ID of the job that fetches the details.\n\"Prefer: respond-sync\" makes the request wait for the API. In sync mode \"Prefer: respond-async\" is ignored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Create a new song",
+                "parameters": [
+                    {
+                        "description": "Song details",
+                        "name": "song",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateSongRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "respond-sync to wait for the song info API in async mode",
+                        "name": "Prefer",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully created song",
+                        "schema": {
+                            "$ref": "#/definitions/models.Id"
+                        }
+                    },
+                    "202": {
+                        "description": "Song stored, its details are being fetched",
+                        "schema": {
+                            "$ref": "#/definitions/models.AcceptedSong"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the job"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request (e.g., missing required fields) or the song info API rejected the song",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "502": {
+                        "description": "Song info API failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}": {
+            "get": {
+                "description": "Retrieves the verses of a song by its ID. With lang, or an Accept-Language that prefers one of the translations of the song, the verses of the translation are returned, each with the verse of the original text it is aligned with.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Retrieve verses of a song by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Start index for verse retrieval (1-based index). Defaults to 1.",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of verses to retrieve, not more than the verses left. Defaults to 1.",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "BCP 47 tag of the translation to read, e.g. en or pt-BR",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages; the original text is used if no translation matches",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached response; 304 is returned if the song hasn't changed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Verses of the song",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Verse"
+                            }
+                        },
+                        "headers": {
+                            "Content-Language": {
+                                "type": "string",
+                                "description": "Language of the translation"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the song and of the translation"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Song hasn't changed"
+                    },
+                    "400": {
+                        "description": "Invalid request parameters or song does not contain requested verses.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Song or translation not found.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces all editable fields of an existing song. Fields missing from the body are cleared; song and group are required.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Replace a song by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the song version the changes are based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "New song fields",
+                        "name": "song",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SongRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully replaced song",
+                        "schema": {
+                            "$ref": "#/definitions/models.Song"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the song"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request (e.g., invalid JSON or missing required fields).",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Song not found.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Song has been modified since the version in If-Match.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error during the update process.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Moves a song to the trash by its ID. It can be restored with POST /songs/{id}/restore until it is purged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Delete a song by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the song version that is expected to be deleted",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Invalid song ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Song not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Song has been modified since the version in If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error during deletion",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Partially updates an existing song in the library by its ID.\nWith Content-Type application/merge-patch+json (or application/json) the body is an RFC 7396 JSON Merge Patch: only the fields present in it change, and null clears a field (text, link, releaseDate). song and group can't be cleared.\nWith Content-Type application/json-patch+json the body is an RFC 6902 JSON Patch, e.g. [{\"op\": \"replace\", \"path\": \"/link\", \"value\": \"\"}].",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Update a song by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the song version the changes are based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Merge patch or JSON patch of the song fields",
+                        "name": "song",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SongRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated song",
+                        "schema": {
+                            "$ref": "#/definitions/models.Song"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the song"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request (e.g., invalid JSON or missing required fields).",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Song not found.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "JSON patch can't be applied to the song (failed test or missing path).",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Song has been modified since the version in If-Match.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Content-Type.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error during the update process.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/chords": {
+            "get": {
+                "description": "Retrieves the chord chart of a song parsed into sections and lines (json), as a ChordPro document (chordpro), as plain text with chords above the lyrics (text) or as an HTML page (html).\ntranspose moves every chord, including slash chords and the key, by that many semitones; the new key decides between sharps and flats. capo rewrites the chords for playing with the capo on that fret instead of the one of the chart, keeping the sound.",
+                "produces": [
+                    "application/json",
+                    "application/x-chordpro",
+                    "text/plain",
+                    "text/html"
+                ],
+                "tags": [
+                    "chords"
+                ],
+                "summary": "Retrieve the chord sheet of a song",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "chordpro",
+                            "text",
+                            "html"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Format of the sheet",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Semitones to move the chords by, from -11 to 11, e.g. +2",
+                        "name": "transpose",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Fret of the capo, from 0 to 11",
+                        "name": "capo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Chord sheet",
+                        "schema": {
+                            "$ref": "#/definitions/models.ChordSheet"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid song ID or query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Song not found or has no chord chart",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Attaches a ChordPro chord chart to a song, replacing the previous one. The body is the ChordPro document (Content-Type application/x-chordpro, text/x-chordpro or text/plain) or JSON (application/json) like {\"source\": \"...\"}.\nChords are written in brackets inside the lyrics, like \"[G]Hello [C/E]world\". The directives title, subtitle, artist, key, capo, comment, chorus and start_of_/end_of_ sections are recognised; tabs and grids are kept verbatim. The plain text of the song is not changed.",
+                "consumes": [
+                    "application/json",
+                    "application/x-chordpro"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chords"
+                ],
+                "summary": "Attach a chord chart to a song",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Chord chart",
+                        "name": "chart",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ChordChartRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Saved chord chart",
+                        "schema": {
+                            "$ref": "#/definitions/models.ChordChart"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid song ID or chord chart",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Song not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Body is too large",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Content-Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes the ChordPro chord chart from a song. The song itself is kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chords"
+                ],
+                "summary": "Delete the chord chart of a song",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Chord chart deleted"
+                    },
+                    "400": {
+                        "description": "Invalid song ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Song not found or has no chord chart",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/lyrics": {
+            "get": {
+                "description": "Retrieves the lines of a song grouped by sections, which are numbered as verses like in GET /songs/{id}/sections. Only the lines matching every given parameter are returned. A range that starts beyond the last verse or line is an error, while its end is limited to the last one. total_verses and total_lines count the whole song. With lang, or an Accept-Language that prefers one of the translations of the song, the lyrics of the translation are returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Retrieve parts of the lyrics of a song",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Verses by number, e.g. 1-3,5 or 4- for the 4th verse to the end",
+                        "name": "verses",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Lines by number, e.g. 10-20",
+                        "name": "lines",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "intro",
+                            "verse",
+                            "pre-chorus",
+                            "chorus",
+                            "bridge",
+                            "outro",
+                            "other"
+                        ],
+                        "type": "string",
+                        "description": "Type of the sections",
+                        "name": "section",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "BCP 47 tag of the translation to read, e.g. en or pt-BR",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred languages; the original text is used if no translation matches",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached response; 304 is returned if the song hasn't changed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Selected lyrics",
+                        "schema": {
+                            "$ref": "#/definitions/models.Lyrics"
+                        },
+                        "headers": {
+                            "Content-Language": {
+                                "type": "string",
+                                "description": "Language of the translation"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the song and of the translation"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Song hasn't changed"
+                    },
+                    "400": {
+                        "description": "Invalid parameters, or the song doesn't have the requested verses or lines",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Song or translation not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/lyrics/at": {
+            "get": {
+                "description": "Returns the line of the synced lyrics sung at the playback position and the next line with text. current is null before the first line, after a line has ended and during pauses; next is null after the last line.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "synced lyrics"
+                ],
+                "summary": "Retrieve the line sung at a playback position",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Playback position in seconds, e.g. 83.5",
+                        "name": "t",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Current and next line",
+                        "schema": {
+                            "$ref": "#/definitions/models.LyricsPosition"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid song ID or position",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Song not found or has no synced lyrics",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/lyrics/synced": {
+            "get": {
+                "description": "Retrieves the lyrics of a song synchronised with playback as JSON, LRC or WebVTT. In LRC the ends of lines followed by a pause are written as empty lines; in WebVTT lines without an end last until the next line.",
+                "produces": [
+                    "application/json",
+                    "application/x-lrc",
+                    "text/vtt"
+                ],
+                "tags": [
+                    "synced lyrics"
+                ],
+                "summary": "Retrieve the synced lyrics of a song",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "lrc",
+                            "vtt"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Format of the lyrics",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Synced lyrics",
+                        "schema": {
+                            "$ref": "#/definitions/models.SyncedLyrics"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid song ID or format",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Song not found or has no synced lyrics",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Attaches lyrics synchronised with playback to a song, replacing the previous ones. The body is LRC (Content-Type application/x-lrc, text/x-lrc or text/plain), WebVTT (text/vtt) or JSON (application/json) with lines like {\"start\": 12.5, \"end\": 15, \"text\": \"...\"}.\nLines must start at strictly increasing times, end after they start and not after the next line starts. In LRC, metadata tags are ignored except [offset:ms], lines without text mark pauses, and a line with several timestamps is repeated at each of them.",
+                "consumes": [
+                    "application/json",
+                    "application/x-lrc",
+                    "text/vtt"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "synced lyrics"
+                ],
+                "summary": "Attach synced lyrics to a song",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Synced lyrics",
+                        "name": "lyrics",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SyncedLyricsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Saved lyrics",
+                        "schema": {
+                            "$ref": "#/definitions/models.SyncedLyrics"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid song ID or lyrics",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Song not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Body is too large",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Content-Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes the lyrics synchronised with playback from a song. The song itself is kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "synced lyrics"
+                ],
+                "summary": "Delete the synced lyrics of a song",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Synced lyrics deleted"
+                    },
+                    "400": {
+                        "description": "Invalid song ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Song not found or has no synced lyrics",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/refresh": {
+            "post": {
+                "description": "Fetches the release date, text and link of the song from the song info API again and returns the fields that changed with their values before and after.\nEmpty fields and fields supplied by a provider are replaced; fields edited by users are kept unless force is set.\nWith dry_run the changes are only reported and the song is left as is.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Refresh song details",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only report the changes",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also replace fields edited by users",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Changes of the song",
+                        "schema": {
+                            "$ref": "#/definitions/models.SongRefresh"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the song"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid song ID or query parameters, or the song info API rejected the song",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Song not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Song has been modified while it was being refreshed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "502": {
+                        "description": "Song info API failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Song info API is unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/restore": {
+            "post": {
+                "description": "Takes a deleted song out of the trash.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Restore a song from the trash",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Restored song",
+                        "schema": {
+                            "$ref": "#/definitions/models.Song"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid song ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Song is not in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/sections": {
+            "get": {
+                "description": "Splits the lyrics into typed sections (intro, verse, pre-chorus, chorus, bridge, outro, other). Sections are detected from labels like \"[Chorus]\", \"Verse 2:\" or \"(Bridge)\", and unlabelled sections that repeat are treated as choruses. Lines are numbered from 1 across the whole song, skipping blank lines and labels.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Retrieve the structure of the lyrics of a song",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached response; 304 is returned if the song hasn't changed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sections of the song",
+                        "schema": {
+                            "$ref": "#/definitions/models.SongSections"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the song"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Song hasn't changed"
+                    },
+                    "400": {
+                        "description": "Invalid song ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Song not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/translations": {
+            "get": {
+                "description": "Retrieves all translations of the text of a song ordered by language. outdated is set for translations made before the song last changed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "translations"
+                ],
+                "summary": "Retrieve the translations of a song",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Translations of the song",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Translation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid song ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Song not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/translations/{lang}": {
+            "get": {
+                "description": "Retrieves the text of a song translated into a language. The tag is matched in its canonical form, so en-us and en_US both mean en-US.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "translations"
+                ],
+                "summary": "Retrieve a translation of a song",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "BCP 47 language tag, e.g. en or pt-BR",
+                        "name": "lang",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Translation",
+                        "schema": {
+                            "$ref": "#/definitions/models.Translation"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid song ID or language tag",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Song or translation not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Stores the text of a song translated into a language, replacing the previous translation into it. Verses are separated by blank lines like in the text of the song, and the translation must have as many verses and as many sections split by labels like [Chorus], so that GET /songs/{id}?lang= and GET /songs/{id}/lyrics?lang= can align them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "translations"
+                ],
+                "summary": "Create or replace a translation of a song",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "BCP 47 language tag, e.g. en or pt-BR",
+                        "name": "lang",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Translated text",
+                        "name": "translation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TranslationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Translation replaced",
+                        "schema": {
+                            "$ref": "#/definitions/models.Translation"
+                        }
+                    },
+                    "201": {
+                        "description": "Translation created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Translation"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the translation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid song ID, language tag or text, or the verses don't match the song",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Song not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes the translation of a song into a language. The song and its other translations are kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "translations"
+                ],
+                "summary": "Delete a translation of a song",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "BCP 47 language tag, e.g. en or pt-BR",
+                        "name": "lang",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Translation deleted"
+                    },
+                    "400": {
+                        "description": "Invalid song ID or language tag",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Song or translation not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/versions": {
+            "get": {
+                "description": "Retrieves all versions of a song, oldest first. A version is recorded on every create, update, delete and restore.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "versions"
+                ],
+                "summary": "Retrieve the change history of a song",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Versions of the song",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SongVersion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid song ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Song has no history",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/versions/{version}": {
+            "get": {
+                "description": "Retrieves the state of a song right after the operation recorded in the given version.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "versions"
+                ],
+                "summary": "Retrieve a version of a song",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Version number, starting from 1",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Version of the song",
+                        "schema": {
+                            "$ref": "#/definitions/models.SongVersion"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid song ID or version",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Version not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/versions/{version}/restore": {
+            "post": {
+                "description": "Brings the song back to the state stored in the given version and records the restore as a new version. Deleted songs are recreated with their old ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "versions"
+                ],
+                "summary": "Restore a version of a song",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Version number, starting from 1",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Restored song",
+                        "schema": {
+                            "$ref": "#/definitions/models.Song"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid song ID or version",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Version not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/status/upstream": {
+            "get": {
+                "description": "Reports the circuit breaker state of the external API used to fetch song details.\nclosed means calls go through, open means calls are rejected until retryAt, half-open means a trial call is being made.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "status"
+                ],
+                "summary": "Status of the song info API",
+                "responses": {
+                    "200": {
+                        "description": "Status of the external API",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.UpstreamStatus"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/trash": {
+            "get": {
+                "description": "Retrieves a page of deleted songs that can still be restored. Songs are purged permanently after the configured retention period.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Retrieve songs in the trash",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of songs on the page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of songs to skip (offset pagination)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from next_cursor of the previous page (cursor pagination)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort keys: id, title (or song), group, release_date; prefix a key with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the total number of songs in the trash",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved songs",
+                        "schema": {
+                            "$ref": "#/definitions/models.SongPage"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "RFC 8288 links to the first, next and previous pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pagination parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.AcceptedSong": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "jobId": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ChordChart": {
+            "type": "object",
+            "properties": {
+                "songId": {
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ChordChartRequest": {
+            "type": "object",
+            "properties": {
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ChordLine": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ChordSegment"
+                    }
+                },
+                "tab": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ChordSection": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ChordLine"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ChordSegment": {
+            "type": "object",
+            "properties": {
+                "chord": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ChordSheet": {
+            "type": "object",
+            "properties": {
+                "artist": {
+                    "type": "string"
+                },
+                "capo": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "lyrics": {
+                    "type": "string"
+                },
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ChordSection"
+                    }
+                },
+                "subtitle": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "transpose": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CreateSongRequest": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string"
+                },
+                "song": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FieldChange": {
+            "type": "object",
+            "properties": {
+                "after": {},
+                "before": {},
+                "field": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Group": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "songCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GroupRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Id": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Job": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "nextRunAt": {
+                    "type": "string"
+                },
+                "songId": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Line": {
+            "type": "object",
+            "properties": {
+                "number": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Lyrics": {
+            "type": "object",
+            "properties": {
+                "lang": {
+                    "type": "string"
+                },
+                "langVersion": {
+                    "type": "integer"
+                },
+                "songId": {
+                    "type": "integer"
+                },
+                "total_lines": {
+                    "type": "integer"
+                },
+                "total_verses": {
+                    "type": "integer"
+                },
+                "verses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LyricsVerse"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LyricsPosition": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "$ref": "#/definitions/models.SyncedLine"
+                },
+                "next": {
+                    "$ref": "#/definitions/models.SyncedLine"
+                },
+                "songId": {
+                    "type": "integer"
+                },
+                "t": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.LyricsVerse": {
+            "type": "object",
+            "properties": {
+                "index": {
+                    "type": "integer"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Line"
+                    }
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Section": {
+            "type": "object",
+            "properties": {
+                "firstLine": {
+                    "description": "1-based number of the first line in the whole song.",
+                    "type": "integer"
+                },
+                "index": {
+                    "description": "1-based number among the sections of the same type.",
+                    "type": "integer"
+                },
+                "label": {
+                    "description": "Label the section had in the text, e.g. \"Chorus 2\".",
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Song": {
+            "type": "object",
+            "properties": {
+                "deletedAt": {
+                    "type": "string"
+                },
+                "enrichedAt": {
+                    "type": "string"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lang": {
+                    "type": "string"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "releaseDate": {
+                    "type": "string"
+                },
+                "snippet": {
+                    "description": "HTML: escaped text with the matched words in \u003cb\u003e\u003c/b\u003e.",
+                    "type": "string"
+                },
+                "song": {
+                    "type": "string"
+                },
+                "sources": {
+                    "$ref": "#/definitions/models.Sources"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SongPage": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Song"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SongRefresh": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "boolean"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldChange"
+                    }
+                },
+                "song": {
+                    "$ref": "#/definitions/models.Song"
+                }
+            }
+        },
+        "models.SongRequest": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string"
+                },
+                "lang": {
+                    "type": "string"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "releaseDate": {
+                    "type": "string"
+                },
+                "song": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SongSections": {
+            "type": "object",
+            "properties": {
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Section"
+                    }
+                },
+                "songId": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SongVersion": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "operation": {
+                    "type": "string"
+                },
+                "song": {
+                    "$ref": "#/definitions/models.Song"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Sources": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "string"
+            }
+        },
+        "models.SyncedLine": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "number"
+                },
+                "start": {
+                    "type": "number"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SyncedLyrics": {
+            "type": "object",
+            "properties": {
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SyncedLine"
+                    }
+                },
+                "songId": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SyncedLyricsRequest": {
+            "type": "object",
+            "properties": {
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SyncedLine"
+                    }
+                }
+            }
+        },
+        "models.Translation": {
+            "type": "object",
+            "properties": {
+                "lang": {
+                    "type": "string"
+                },
+                "outdated": {
+                    "type": "boolean"
+                },
+                "songId": {
+                    "type": "integer"
+                },
+                "songVersion": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TranslationRequest": {
+            "type": "object",
+            "properties": {
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.UpstreamStatus": {
+            "type": "object",
+            "properties": {
+                "consecutiveFailures": {
+                    "type": "integer"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "retryAt": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Verse": {
+            "type": "object",
+            "properties": {
+                "original": {
+                    "description": "The verse of the original text, if Verse is translated.",
+                    "type": "string"
+                },
                 "verse": {
                     "type": "string"
                 }
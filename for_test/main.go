@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -27,7 +28,7 @@ func main() {
 		Text:        "Ooh baby, don't you know I suffer?\nOoh baby, can you hear me moan?\nYou caught me under false pretenses\nHow long before you let me go?\n\nOoh\nYou set my soul alight\nOoh\nYou set my soul alight",
 		Link:        "https://www.youtube.com/watch?v=Xsp3_a-PMTw",
 	}
-	id, err := db.CreateSong(&song)
+	id, err := db.CreateSong(context.Background(), &song)
 	if err != nil {
 		panic(err)
 	}
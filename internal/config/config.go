@@ -2,17 +2,72 @@ package config
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
+// Supported values of the STORAGE variable.
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+)
+
+// Supported values of the ENRICH_MODE variable. In sync mode POST /songs waits
+// for the song info API, in async mode it stores the song right away and
+// fetches the details in the background. Only async mode runs the workers.
+const (
+	EnrichSync  = "sync"
+	EnrichAsync = "async"
+)
+
+// Supported values of the API_PROVIDERS_MODE variable.
+const (
+	ProvidersPriority = "priority"
+	ProvidersParallel = "parallel"
+)
+
 type Config struct {
-	DbPort, ServerPort                                                        int
-	DbUser, DbName, DbHost, DbPassword, ApiAddrURL, MigrationPath, ServerHost string
-	Timeout, IdleTimeout                                                      time.Duration
+	DbPort, ServerPort                                            int
+	DbUser, DbName, DbHost, DbPassword, MigrationPath, ServerHost string
+	Storage                                                       string
+	Timeout, IdleTimeout                                          time.Duration
+	DbTimeout, ApiTimeout                                         time.Duration
+	TrashRetention, TrashPurgeInterval                            time.Duration
+	ApiRetries, ApiBreakerThreshold                               int
+	ApiBackoffBase, ApiBackoffMax, ApiBreakerCooldown             time.Duration
+
+	// Song info APIs, in priority order.
+	ApiProviders     []ApiProvider
+	ApiProvidersMode string
+
+	// Cache of the song info API.
+	ApiCacheSize                     int
+	ApiCacheTTL, ApiCacheNegativeTTL time.Duration
+	ApiCachePersist                  bool
+
+	// Background enrichment of songs.
+	EnrichMode                           string
+	EnrichWorkers, EnrichMaxAttempts     int
+	EnrichRetryDelay, EnrichPollInterval time.Duration
+
+	// Scheduled refresh of song details.
+	RefreshInterval, RefreshMaxAge, RefreshEmptyAge time.Duration
+	RefreshBatch                                    int
+}
+
+// ApiProvider describes one song info API.
+type ApiProvider struct {
+	Name, AddrURL                    string
+	InfoPath, TitleParam, GroupParam string
+	Key, KeyHeader, KeyParam         string
+	Headers                          map[string]string
+	MapReleaseDate, MapText, MapLink string
+	ReleaseDateLayout                string
 }
 
 func LoadConfig() (*Config, error) {
@@ -39,21 +94,359 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	dbTimeout, err := getPositiveDuration("DB_TIMEOUT", 5, time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	apiTimeout, err := getPositiveDuration("API_TIMEOUT", 10, time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	apiRetries, err := getInt("API_RETRIES", 3)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	apiBackoffBase, err := getPositiveDuration("API_BACKOFF_BASE", 200, time.Millisecond)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	apiBackoffMax, err := getPositiveDuration("API_BACKOFF_MAX", 5000, time.Millisecond)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	apiBreakerThreshold, err := getInt("API_BREAKER_THRESHOLD", 5)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	apiBreakerCooldown, err := getPositiveDuration("API_BREAKER_COOLDOWN", 30, time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	apiProviders, err := getProviders("API_PROVIDERS")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	apiProvidersMode := os.Getenv("API_PROVIDERS_MODE")
+	switch apiProvidersMode {
+	case "":
+		apiProvidersMode = ProvidersPriority
+	case ProvidersPriority, ProvidersParallel:
+	default:
+		return nil, fmt.Errorf("%s: unknown providers mode %q", op, apiProvidersMode)
+	}
+
+	apiCacheSize, err := getInt("API_CACHE_SIZE", 10000)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	apiCacheTTL, err := getDuration("API_CACHE_TTL", 24*60, time.Minute)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	apiCacheNegativeTTL, err := getDuration("API_CACHE_NEGATIVE_TTL", 60, time.Minute)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	apiCachePersist, err := getBool("API_CACHE_PERSIST", false)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	trashRetention, err := getPositiveDuration("TRASH_RETENTION", 30*24, time.Hour)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	trashPurgeInterval, err := getDuration("TRASH_PURGE_INTERVAL", 60, time.Minute)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	enrichWorkers, err := getPositiveInt("ENRICH_WORKERS", 4)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	enrichMaxAttempts, err := getPositiveInt("ENRICH_MAX_ATTEMPTS", 5)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	enrichRetryDelay, err := getPositiveDuration("ENRICH_RETRY_DELAY", 30, time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	enrichPollInterval, err := getPositiveDuration("ENRICH_POLL_INTERVAL", 5, time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	refreshInterval, err := getDuration("REFRESH_INTERVAL", 60, time.Minute)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	refreshMaxAge, err := getPositiveDuration("REFRESH_MAX_AGE", 30*24, time.Hour)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	refreshEmptyAge, err := getPositiveDuration("REFRESH_EMPTY_AGE", 24, time.Hour)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	refreshBatch, err := getPositiveInt("REFRESH_BATCH", 100)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	enrichMode := os.Getenv("ENRICH_MODE")
+	switch enrichMode {
+	case "":
+		enrichMode = EnrichSync
+	case EnrichSync, EnrichAsync:
+	default:
+		return nil, fmt.Errorf("%s: unknown enrich mode %q", op, enrichMode)
+	}
+
+	storage := os.Getenv("STORAGE")
+	switch storage {
+	case "":
+		storage = StoragePostgres
+	case StoragePostgres, StorageMemory:
+	default:
+		return nil, fmt.Errorf("%s: unknown storage %q", op, storage)
+	}
+
 	return &Config{
-		DbPort:        dbPort,
-		ServerPort:    serverPort,
-		DbUser:        os.Getenv("DB_USER"),
-		DbName:        os.Getenv("DB_NAME"),
-		DbHost:        os.Getenv("DB_HOST"),
-		DbPassword:    os.Getenv("DB_PASSWORD"),
-		ApiAddrURL:    os.Getenv("API_ADDR_URL"),
-		MigrationPath: os.Getenv("MIGRATION_PATH"),
-		ServerHost:    os.Getenv("SERVER_HOST"),
-		Timeout:       time.Duration(timeOut) * time.Second,
-		IdleTimeout:   time.Duration(idleTimeout) * time.Second,
+		DbPort:              dbPort,
+		ServerPort:          serverPort,
+		DbUser:              os.Getenv("DB_USER"),
+		DbName:              os.Getenv("DB_NAME"),
+		DbHost:              os.Getenv("DB_HOST"),
+		DbPassword:          os.Getenv("DB_PASSWORD"),
+		MigrationPath:       os.Getenv("MIGRATION_PATH"),
+		ServerHost:          os.Getenv("SERVER_HOST"),
+		Storage:             storage,
+		Timeout:             time.Duration(timeOut) * time.Second,
+		IdleTimeout:         time.Duration(idleTimeout) * time.Second,
+		DbTimeout:           dbTimeout,
+		ApiTimeout:          apiTimeout,
+		TrashRetention:      trashRetention,
+		TrashPurgeInterval:  trashPurgeInterval,
+		ApiRetries:          apiRetries,
+		ApiBackoffBase:      apiBackoffBase,
+		ApiBackoffMax:       apiBackoffMax,
+		ApiBreakerThreshold: apiBreakerThreshold,
+		ApiBreakerCooldown:  apiBreakerCooldown,
+		ApiProviders:        apiProviders,
+		ApiProvidersMode:    apiProvidersMode,
+		ApiCacheSize:        apiCacheSize,
+		ApiCacheTTL:         apiCacheTTL,
+		ApiCacheNegativeTTL: apiCacheNegativeTTL,
+		ApiCachePersist:     apiCachePersist,
+		EnrichMode:          enrichMode,
+		EnrichWorkers:       enrichWorkers,
+		EnrichMaxAttempts:   enrichMaxAttempts,
+		EnrichRetryDelay:    enrichRetryDelay,
+		EnrichPollInterval:  enrichPollInterval,
+		RefreshInterval:     refreshInterval,
+		RefreshMaxAge:       refreshMaxAge,
+		RefreshEmptyAge:     refreshEmptyAge,
+		RefreshBatch:        refreshBatch,
 	}, nil
 }
 
+// getDuration reads an optional non-negative duration given as a whole
+// number of units, falling back to defaultValue when the variable is not set.
+// It is used for the settings that 0 disables: API_CACHE_TTL,
+// API_CACHE_NEGATIVE_TTL, TRASH_PURGE_INTERVAL and REFRESH_INTERVAL.
+func getDuration(key string, defaultValue int, unit time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return time.Duration(defaultValue) * unit, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", key, err)
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("%s: must not be negative", key)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// getPositiveDuration is getDuration for the settings that 0 doesn't disable,
+// like DB_TIMEOUT, so that 0 is rejected too.
+func getPositiveDuration(key string, defaultValue int, unit time.Duration) (time.Duration, error) {
+	d, err := getDuration(key, defaultValue, unit)
+	if err != nil {
+		return 0, err
+	}
+	if d == 0 {
+		return 0, fmt.Errorf("%s: must be positive", key)
+	}
+	return d, nil
+}
+
+// getInt reads an optional non-negative integer, falling back to
+// defaultValue when the variable is not set. It is used for the settings
+// that 0 disables or turns off: API_RETRIES, API_BREAKER_THRESHOLD and
+// API_CACHE_SIZE.
+func getInt(key string, defaultValue int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", key, err)
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("%s: must not be negative", key)
+	}
+	return n, nil
+}
+
+// getPositiveInt is getInt for the settings that 0 doesn't disable, like
+// ENRICH_WORKERS, so that 0 is rejected too.
+func getPositiveInt(key string, defaultValue int) (int, error) {
+	n, err := getInt(key, defaultValue)
+	if err != nil {
+		return 0, err
+	}
+	if n == 0 {
+		return 0, fmt.Errorf("%s: must be positive", key)
+	}
+	return n, nil
+}
+
+// DefaultProvider is the name of the only provider when API_PROVIDERS isn't set.
+const DefaultProvider = "default"
+
+// getProviders reads the song info APIs listed in key as comma-separated
+// names. Every provider is configured by the API_* variables prefixed with
+// its name, e.g. API_LYRICS_ADDR_URL for the provider "lyrics". Without the
+// list there is a single provider configured by the unprefixed variables.
+func getProviders(key string) ([]ApiProvider, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		provider, err := getProvider(DefaultProvider, "API_")
+		if err != nil {
+			return nil, err
+		}
+		return []ApiProvider{provider}, nil
+	}
+
+	var providers []ApiProvider
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("%s: duplicate provider %q", key, name)
+		}
+		seen[name] = true
+
+		prefix := "API_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		provider, err := getProvider(name, prefix)
+		if err != nil {
+			return nil, err
+		}
+		if provider.AddrURL == "" {
+			return nil, fmt.Errorf("%s: provider %q: %sADDR_URL is not set", key, name, prefix)
+		}
+		providers = append(providers, provider)
+	}
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("%s: no providers", key)
+	}
+	return providers, nil
+}
+
+// getProvider reads the variables of a provider that start with prefix.
+func getProvider(name, prefix string) (ApiProvider, error) {
+	headers, err := getHeaders(prefix + "HEADERS")
+	if err != nil {
+		return ApiProvider{}, err
+	}
+	return ApiProvider{
+		Name:              name,
+		AddrURL:           os.Getenv(prefix + "ADDR_URL"),
+		InfoPath:          os.Getenv(prefix + "INFO_PATH"),
+		TitleParam:        os.Getenv(prefix + "TITLE_PARAM"),
+		GroupParam:        os.Getenv(prefix + "GROUP_PARAM"),
+		Key:               os.Getenv(prefix + "KEY"),
+		KeyHeader:         os.Getenv(prefix + "KEY_HEADER"),
+		KeyParam:          os.Getenv(prefix + "KEY_PARAM"),
+		Headers:           headers,
+		MapReleaseDate:    os.Getenv(prefix + "MAP_RELEASE_DATE"),
+		MapText:           os.Getenv(prefix + "MAP_TEXT"),
+		MapLink:           os.Getenv(prefix + "MAP_LINK"),
+		ReleaseDateLayout: os.Getenv(prefix + "RELEASE_DATE_LAYOUT"),
+	}, nil
+}
+
+// getBool reads an optional boolean, falling back to defaultValue when the
+// variable is not set.
+func getBool(key string, defaultValue bool) (bool, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", key, err)
+	}
+	return b, nil
+}
+
+// getHeaders reads optional HTTP headers written as
+// "Name: value; Other-Name: value".
+func getHeaders(key string) (map[string]string, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil, nil
+	}
+	headers := make(map[string]string)
+	for _, header := range strings.Split(value, ";") {
+		if strings.TrimSpace(header) == "" {
+			continue
+		}
+		name, val, ok := strings.Cut(header, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%s: invalid header %q", key, header)
+		}
+		headers[name] = strings.TrimSpace(val)
+	}
+	return headers, nil
+}
+
+// LogValue hides secrets when the configuration is logged.
+func (c Config) LogValue() slog.Value {
+	const hidden = "***"
+	if c.DbPassword != "" {
+		c.DbPassword = hidden
+	}
+	providers := make([]ApiProvider, len(c.ApiProviders))
+	for i, p := range c.ApiProviders {
+		if p.Key != "" {
+			p.Key = hidden
+		}
+		if len(p.Headers) > 0 {
+			headers := make(map[string]string, len(p.Headers))
+			for name := range p.Headers {
+				headers[name] = hidden
+			}
+			p.Headers = headers
+		}
+		providers[i] = p
+	}
+	c.ApiProviders = providers
+	type config Config // Without the LogValue method, so that it isn't called again.
+	return slog.AnyValue(config(c))
+}
+
 func MustLoadConfig() *Config {
 	config, err := LoadConfig()
 	if err != nil {
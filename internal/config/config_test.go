@@ -0,0 +1,78 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		positive bool
+		want     time.Duration
+		wantErr  bool
+	}{
+		{name: "default", value: "", want: 5 * time.Second},
+		{name: "set", value: "7", want: 7 * time.Second},
+		{name: "zero disables", value: "0", want: 0},
+		{name: "negative", value: "-1", wantErr: true},
+		{name: "not a number", value: "5s", wantErr: true},
+		{name: "positive default", value: "", positive: true, want: 5 * time.Second},
+		{name: "positive set", value: "7", positive: true, want: 7 * time.Second},
+		{name: "positive zero", value: "0", positive: true, wantErr: true},
+		{name: "positive negative", value: "-1", positive: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_DURATION", tt.value)
+			read := getDuration
+			if tt.positive {
+				read = getPositiveDuration
+			}
+			got, err := read("TEST_DURATION", 5, time.Second)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetInt(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		positive bool
+		want     int
+		wantErr  bool
+	}{
+		{name: "default", value: "", want: 3},
+		{name: "set", value: "7", want: 7},
+		{name: "zero", value: "0", want: 0},
+		{name: "negative", value: "-1", wantErr: true},
+		{name: "not a number", value: "many", wantErr: true},
+		{name: "positive default", value: "", positive: true, want: 3},
+		{name: "positive set", value: "7", positive: true, want: 7},
+		{name: "positive zero", value: "0", positive: true, wantErr: true},
+		{name: "positive negative", value: "-1", positive: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_INT", tt.value)
+			read := getInt
+			if tt.positive {
+				read = getPositiveInt
+			}
+			got, err := read("TEST_INT", 3)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,11 +1,95 @@
 package database
 
-import "github.com/notblinkyet/song-library-api/internal/models"
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// Errors shared by every Storage implementation.
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrAlreadyExists   = errors.New("already exists")
+	ErrGroupNotEmpty   = errors.New("group still has songs")
+	ErrVersionMismatch = errors.New("song has been modified")
+)
 
 type Storage interface {
-	ReadFilteredSongs(filter *models.Filter) ([]models.Song, error)
-	ReadByID(id int) (*models.Song, error)
-	DeleteSong(id int) error
-	UpdateSong(song *models.Song) error
-	CreateSong(song *models.Song) (int, error)
+	SongStorage
+	GroupStorage
+	VersionStorage
+	JobStorage
+	SectionStorage
+	SyncedLyricsStorage
+	ChordChartStorage
+	TranslationStorage
+}
+
+type SongStorage interface {
+	ReadFilteredSongs(ctx context.Context, filter *models.Filter) ([]models.Song, error)
+	CountSongs(ctx context.Context, filter *models.Filter) (int, error)
+	ReadByID(ctx context.Context, id int) (*models.Song, error)
+	DeleteSong(ctx context.Context, id, version int) error
+	UpdateSong(ctx context.Context, song *models.Song) error
+	CreateSong(ctx context.Context, song *models.Song) (int, error)
+	RestoreSong(ctx context.Context, id int) error
+	PurgeDeletedSongs(ctx context.Context, before time.Time) (int, error)
+	RefreshSong(ctx context.Context, song *models.Song) error
+	MarkSongRefreshed(ctx context.Context, id int) error
+	ReadStaleSongs(ctx context.Context, before, emptyBefore time.Time, limit int) ([]models.Song, error)
+}
+
+type GroupStorage interface {
+	ReadGroups(ctx context.Context) ([]models.Group, error)
+	ReadGroupByID(ctx context.Context, id int) (*models.Group, error)
+	CreateGroup(ctx context.Context, name string) (int, error)
+	UpdateGroup(ctx context.Context, group *models.Group) error
+	DeleteGroup(ctx context.Context, id int) error
+}
+
+type VersionStorage interface {
+	ReadSongVersions(ctx context.Context, songID int) ([]models.SongVersion, error)
+	ReadSongVersion(ctx context.Context, songID, version int) (*models.SongVersion, error)
+	RestoreSongVersion(ctx context.Context, songID, version int) (*models.Song, error)
+}
+
+// JobStorage keeps the queue of songs whose details are fetched in the background.
+type JobStorage interface {
+	CreatePendingSong(ctx context.Context, song *models.Song) (*models.Job, error)
+	ReadJob(ctx context.Context, id int) (*models.Job, error)
+	ClaimJob(ctx context.Context, lease time.Duration) (*models.Job, error)
+	CompleteJob(ctx context.Context, id int, info *models.Song) error
+	RetryJob(ctx context.Context, id int, lastError string, at time.Time) error
+	FailJob(ctx context.Context, id int, lastError string) error
+}
+
+// SectionStorage keeps the parsed structure of the lyrics of songs.
+type SectionStorage interface {
+	ReadSongSections(ctx context.Context, songID int) (*models.SongSections, error)
+	SaveSongSections(ctx context.Context, sections *models.SongSections) error
+}
+
+// SyncedLyricsStorage keeps the lyrics of songs synchronised with playback.
+type SyncedLyricsStorage interface {
+	ReadSyncedLyrics(ctx context.Context, songID int) (*models.SyncedLyrics, error)
+	SaveSyncedLyrics(ctx context.Context, lyrics *models.SyncedLyrics) error
+	DeleteSyncedLyrics(ctx context.Context, songID int) error
+}
+
+// ChordChartStorage keeps the ChordPro chord charts of songs.
+type ChordChartStorage interface {
+	ReadChordChart(ctx context.Context, songID int) (*models.ChordChart, error)
+	SaveChordChart(ctx context.Context, chart *models.ChordChart) error
+	DeleteChordChart(ctx context.Context, songID int) error
+}
+
+// TranslationStorage keeps the translations of the texts of songs, keyed by
+// song and canonical BCP 47 language tag.
+type TranslationStorage interface {
+	ReadTranslations(ctx context.Context, songID int) ([]models.Translation, error)
+	ReadTranslation(ctx context.Context, songID int, lang string) (*models.Translation, error)
+	SaveTranslation(ctx context.Context, translation *models.Translation) (created bool, err error)
+	DeleteTranslation(ctx context.Context, songID int, lang string) error
 }
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+func (m *Memory) ReadChordChart(_ context.Context, songID int) (*models.ChordChart, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	s, ok := m.songs[songID]
+	if !ok || s.deletedAt != nil {
+		return nil, ErrNotFound
+	}
+	stored, ok := m.chordCharts[songID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	res := *stored
+	return &res, nil
+}
+
+func (m *Memory) SaveChordChart(_ context.Context, chart *models.ChordChart) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.songs[chart.SongID]
+	if !ok || s.deletedAt != nil {
+		return ErrNotFound
+	}
+	chart.UpdatedAt = time.Now()
+	res := *chart
+	m.chordCharts[chart.SongID] = &res
+	return nil
+}
+
+func (m *Memory) DeleteChordChart(_ context.Context, songID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.songs[songID]
+	if !ok || s.deletedAt != nil {
+		return ErrNotFound
+	}
+	if _, ok = m.chordCharts[songID]; !ok {
+		return ErrNotFound
+	}
+	delete(m.chordCharts, songID)
+	return nil
+}
@@ -0,0 +1,31 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+func (m *Memory) CreateSong(_ context.Context, s *models.Song) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	id := m.nextSongID
+	m.nextSongID++
+	m.songs[id] = &song{
+		id:          id,
+		title:       s.Title,
+		groupID:     m.groupID(s.Group),
+		releaseDate: s.ReleaseDate,
+		text:        s.Text,
+		link:        s.Link,
+		version:     1,
+		status:      models.SongStatusReady,
+		sources:     s.Sources,
+		enrichedAt:  &now,
+	}
+	m.recordVersion(id, models.OperationCreate)
+	return id, nil
+}
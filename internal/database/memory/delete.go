@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+func (m *Memory) DeleteSong(_ context.Context, id, version int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.songs[id]
+	if !ok || s.deletedAt != nil {
+		return ErrNotFound
+	}
+	if version != 0 && version != s.version {
+		return ErrVersionMismatch
+	}
+	now := time.Now()
+	s.deletedAt = &now
+	s.version++
+	m.recordVersion(id, models.OperationDelete)
+	return nil
+}
+
+func (m *Memory) RestoreSong(_ context.Context, id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.songs[id]
+	if !ok || s.deletedAt == nil {
+		return ErrNotFound
+	}
+	s.deletedAt = nil
+	s.version++
+	m.recordVersion(id, models.OperationRestore)
+	return nil
+}
+
+func (m *Memory) PurgeDeletedSongs(_ context.Context, before time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	purged := 0
+	for id, s := range m.songs {
+		if s.deletedAt != nil && s.deletedAt.Before(before) {
+			delete(m.songs, id)
+			m.deleteJobs(id)
+			delete(m.sections, id)
+			delete(m.syncedLyrics, id)
+			delete(m.chordCharts, id)
+			delete(m.translations, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPurgeDeletedSongs(t *testing.T) {
+	m := newTestMemory(t, "Uprising", "Starlight", "Hysteria")
+	ctx := context.Background()
+
+	for _, id := range []int{1, 2} {
+		if err := m.DeleteSong(ctx, id, 0); err != nil {
+			t.Fatalf("failed to delete song: %v", err)
+		}
+	}
+	// The first song went to the trash long ago.
+	longAgo := time.Now().Add(-48 * time.Hour)
+	m.songs[1].deletedAt = &longAgo
+
+	purged, err := m.PurgeDeletedSongs(ctx, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("failed to purge songs: %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("purged %d songs, want 1", purged)
+	}
+	if _, ok := m.songs[1]; ok {
+		t.Errorf("the song deleted long ago is still stored")
+	}
+	if _, ok := m.songs[2]; !ok {
+		t.Errorf("the song deleted recently has been purged")
+	}
+	if err = m.RestoreSong(ctx, 1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("restoring a purged song: error = %v, want %v", err, ErrNotFound)
+	}
+	if versions, err := m.ReadSongVersions(ctx, 1); err != nil || len(versions) != 2 {
+		t.Errorf("versions of the purged song = %d, %v, want 2", len(versions), err)
+	}
+	if _, err = m.ReadByID(ctx, 3); err != nil {
+		t.Errorf("the song not deleted: %v", err)
+	}
+}
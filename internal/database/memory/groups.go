@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+func (m *Memory) ReadGroups(_ context.Context) ([]models.Group, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ids := make([]int, 0, len(m.groups))
+	for id := range m.groups {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	counts := m.songCounts(false)
+	groups := make([]models.Group, 0, len(ids))
+	for _, id := range ids {
+		groups = append(groups, models.Group{
+			ID:        id,
+			Name:      m.groups[id],
+			SongCount: counts[id],
+		})
+	}
+	return groups, nil
+}
+
+func (m *Memory) ReadGroupByID(_ context.Context, id int) (*models.Group, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	name, ok := m.groups[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &models.Group{
+		ID:        id,
+		Name:      name,
+		SongCount: m.songCounts(false)[id],
+	}, nil
+}
+
+func (m *Memory) CreateGroup(_ context.Context, name string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.groupIDs[name]; ok {
+		return 0, ErrAlreadyExists
+	}
+	return m.groupID(name), nil
+}
+
+func (m *Memory) UpdateGroup(_ context.Context, group *models.Group) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	oldName, ok := m.groups[group.ID]
+	if !ok {
+		return ErrNotFound
+	}
+	if id, ok := m.groupIDs[group.Name]; ok && id != group.ID {
+		return ErrAlreadyExists
+	}
+	delete(m.groupIDs, oldName)
+	m.groups[group.ID] = group.Name
+	m.groupIDs[group.Name] = group.ID
+	return nil
+}
+
+func (m *Memory) DeleteGroup(_ context.Context, id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name, ok := m.groups[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if m.songCounts(true)[id] > 0 {
+		return ErrGroupNotEmpty
+	}
+	delete(m.groups, id)
+	delete(m.groupIDs, name)
+	return nil
+}
+
+// songCounts returns the number of songs per group id, optionally counting
+// the songs in the trash. The caller must hold at least the read lock.
+func (m *Memory) songCounts(withDeleted bool) map[int]int {
+	counts := make(map[int]int, len(m.groups))
+	for _, s := range m.songs {
+		if s.deletedAt == nil || withDeleted {
+			counts[s.groupID]++
+		}
+	}
+	return counts
+}
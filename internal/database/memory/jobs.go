@@ -0,0 +1,185 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// job mirrors a row of the enrichment_jobs table.
+type job struct {
+	models.Job
+	lockedUntil time.Time
+}
+
+func (m *Memory) CreatePendingSong(_ context.Context, s *models.Song) (*models.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := m.nextSongID
+	m.nextSongID++
+	m.songs[id] = &song{
+		id:          id,
+		title:       s.Title,
+		groupID:     m.groupID(s.Group),
+		releaseDate: s.ReleaseDate,
+		text:        s.Text,
+		link:        s.Link,
+		version:     1,
+		status:      models.SongStatusPending,
+	}
+	m.recordVersion(id, models.OperationCreate)
+
+	now := time.Now()
+	j := &job{Job: models.Job{
+		ID:        m.nextJobID,
+		SongID:    id,
+		Status:    models.JobStatusQueued,
+		NextRunAt: now,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}}
+	m.nextJobID++
+	m.jobs[j.ID] = j
+	res := j.Job
+	return &res, nil
+}
+
+func (m *Memory) ReadJob(_ context.Context, id int) (*models.Job, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	j, ok := m.jobs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	res := j.Job
+	return &res, nil
+}
+
+func (m *Memory) ClaimJob(_ context.Context, lease time.Duration) (*models.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	var next *job
+	for _, j := range m.jobs {
+		due := (j.Status == models.JobStatusQueued && !j.NextRunAt.After(now)) ||
+			(j.Status == models.JobStatusRunning && j.lockedUntil.Before(now))
+		if s, ok := m.songs[j.SongID]; !ok || s.deletedAt != nil {
+			// Jobs of songs in the trash wait until the song is restored.
+			due = false
+		}
+		if due && (next == nil || j.NextRunAt.Before(next.NextRunAt)) {
+			next = j
+		}
+	}
+	if next == nil {
+		return nil, nil
+	}
+
+	next.Status = models.JobStatusRunning
+	next.Attempts++
+	next.lockedUntil = now.Add(lease)
+	next.UpdatedAt = now
+	res := next.Job
+	return &res, nil
+}
+
+func (m *Memory) CompleteJob(_ context.Context, id int, info *models.Song) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, ok := m.jobs[id]
+	if !ok {
+		return ErrNotFound
+	}
+	s, ok := m.songs[j.SongID]
+	if !ok || s.deletedAt != nil {
+		return ErrNotFound
+	}
+
+	j.Status = models.JobStatusSucceeded
+	j.LastError = ""
+	j.lockedUntil = time.Time{}
+	j.UpdatedAt = time.Now()
+
+	// Changes made to the song while it was pending take precedence.
+	sources := make(models.Sources, len(s.sources))
+	for field, provider := range s.sources {
+		sources[field] = provider
+	}
+	fill := func(empty bool, field string) {
+		if empty {
+			if provider, ok := info.Sources[field]; ok {
+				sources[field] = provider
+			}
+		}
+	}
+	fill(s.releaseDate.IsZero(), models.FieldReleaseDate)
+	fill(s.text == "", models.FieldText)
+	fill(s.link == "", models.FieldLink)
+	s.sources = sources
+
+	if s.releaseDate.IsZero() {
+		s.releaseDate = info.ReleaseDate
+	}
+	if s.text == "" {
+		s.text = info.Text
+	}
+	if s.link == "" {
+		s.link = info.Link
+	}
+	now := time.Now()
+	s.status = models.SongStatusReady
+	s.enrichedAt = &now
+	s.version++
+	m.recordVersion(s.id, models.OperationEnrich)
+	return nil
+}
+
+func (m *Memory) RetryJob(_ context.Context, id int, lastError string, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, ok := m.jobs[id]
+	if !ok {
+		return ErrNotFound
+	}
+	j.Status = models.JobStatusQueued
+	j.LastError = lastError
+	j.NextRunAt = at
+	j.lockedUntil = time.Time{}
+	j.UpdatedAt = time.Now()
+	return nil
+}
+
+func (m *Memory) FailJob(_ context.Context, id int, lastError string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, ok := m.jobs[id]
+	if !ok {
+		return ErrNotFound
+	}
+	j.Status = models.JobStatusFailed
+	j.LastError = lastError
+	j.lockedUntil = time.Time{}
+	j.UpdatedAt = time.Now()
+
+	if s, ok := m.songs[j.SongID]; ok && s.status == models.SongStatusPending {
+		s.status = models.SongStatusFailed
+	}
+	return nil
+}
+
+// deleteJobs removes the jobs of a purged song, like ON DELETE CASCADE does.
+// The caller must hold the write lock.
+func (m *Memory) deleteJobs(songID int) {
+	for id, j := range m.jobs {
+		if j.SongID == songID {
+			delete(m.jobs, id)
+		}
+	}
+}
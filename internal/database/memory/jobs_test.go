@@ -0,0 +1,136 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// createPendingSongs creates a pending song for every title and returns
+// the IDs of their jobs.
+func createPendingSongs(t *testing.T, m *Memory, titles ...string) []int {
+	t.Helper()
+	ids := make([]int, len(titles))
+	for i, title := range titles {
+		job, err := m.CreatePendingSong(context.Background(), &models.Song{Title: title, Group: "Muse"})
+		if err != nil {
+			t.Fatalf("failed to create pending song: %v", err)
+		}
+		ids[i] = job.ID
+	}
+	return ids
+}
+
+func TestClaimJob(t *testing.T) {
+	m := newTestMemory(t)
+	ctx := context.Background()
+	ids := createPendingSongs(t, m, "Uprising", "Starlight", "Hysteria", "Madness")
+
+	now := time.Now()
+	m.jobs[ids[0]].NextRunAt = now.Add(-time.Minute)
+	m.jobs[ids[1]].NextRunAt = now.Add(-time.Hour)
+	m.jobs[ids[2]].NextRunAt = now.Add(time.Hour)
+	if err := m.DeleteSong(ctx, m.jobs[ids[3]].SongID, 0); err != nil {
+		t.Fatalf("failed to delete song: %v", err)
+	}
+
+	claim := func(lease time.Duration) *models.Job {
+		t.Helper()
+		job, err := m.ClaimJob(ctx, lease)
+		if err != nil {
+			t.Fatalf("failed to claim job: %v", err)
+		}
+		return job
+	}
+
+	// The job waiting longest comes first.
+	if job := claim(time.Minute); job == nil || job.ID != ids[1] || job.Status != models.JobStatusRunning || job.Attempts != 1 {
+		t.Errorf("first claim = %+v, want job %d", job, ids[1])
+	}
+	if job := claim(-time.Second); job == nil || job.ID != ids[0] {
+		t.Errorf("second claim = %+v, want job %d", job, ids[0])
+	}
+	// The lease of the second job has run out, the third job isn't due and
+	// the song of the fourth one is in the trash.
+	if job := claim(time.Minute); job == nil || job.ID != ids[0] || job.Attempts != 2 {
+		t.Errorf("claim after the lease = %+v, want job %d on its second attempt", job, ids[0])
+	}
+	if job := claim(time.Minute); job != nil {
+		t.Errorf("claim with no due jobs = %+v, want none", job)
+	}
+
+	if err := m.RestoreSong(ctx, m.jobs[ids[3]].SongID); err != nil {
+		t.Fatalf("failed to restore song: %v", err)
+	}
+	if job := claim(time.Minute); job == nil || job.ID != ids[3] {
+		t.Errorf("claim after the restore = %+v, want job %d", job, ids[3])
+	}
+}
+
+func TestCompleteJob(t *testing.T) {
+	m := newTestMemory(t)
+	ctx := context.Background()
+	ids := createPendingSongs(t, m, "Uprising", "Starlight")
+
+	job, err := m.ReadJob(ctx, ids[0])
+	if err != nil {
+		t.Fatalf("failed to read job: %v", err)
+	}
+	// The text set while the song was pending is kept.
+	song, err := m.ReadByID(ctx, job.SongID)
+	if err != nil {
+		t.Fatalf("failed to read song: %v", err)
+	}
+	song.Text = "Paranoia is in bloom"
+	if err = m.UpdateSong(ctx, song); err != nil {
+		t.Fatalf("failed to update song: %v", err)
+	}
+
+	info := &models.Song{
+		ReleaseDate: time.Date(2009, time.September, 7, 0, 0, 0, 0, time.UTC),
+		Text:        "The PR transmissions will resume",
+		Link:        "https://example.com",
+		Sources:     models.Sources{models.FieldReleaseDate: "api", models.FieldText: "api", models.FieldLink: "api"},
+	}
+	if err = m.CompleteJob(ctx, job.ID, info); err != nil {
+		t.Fatalf("failed to complete job: %v", err)
+	}
+
+	got, err := m.ReadByID(ctx, job.SongID)
+	if err != nil {
+		t.Fatalf("failed to read song: %v", err)
+	}
+	if got.Status != models.SongStatusReady || got.EnrichedAt == nil || got.Version != song.Version+1 {
+		t.Errorf("song = %+v, want ready and enriched at version %d", got, song.Version+1)
+	}
+	if got.Text != "Paranoia is in bloom" || got.Link != info.Link || !got.ReleaseDate.Equal(info.ReleaseDate) {
+		t.Errorf("song = %+v, want the text kept and the rest filled in", got)
+	}
+	if _, ok := got.Sources[models.FieldText]; ok || len(got.Sources) != 2 {
+		t.Errorf("sources = %v, want the release date and the link", got.Sources)
+	}
+	if job, err = m.ReadJob(ctx, job.ID); err != nil || job.Status != models.JobStatusSucceeded {
+		t.Errorf("job = %+v, %v", job, err)
+	}
+
+	// The song of the second job goes to the trash before the job is done.
+	job, err = m.ReadJob(ctx, ids[1])
+	if err != nil {
+		t.Fatalf("failed to read job: %v", err)
+	}
+	if err = m.DeleteSong(ctx, job.SongID, 0); err != nil {
+		t.Fatalf("failed to delete song: %v", err)
+	}
+	if err = m.CompleteJob(ctx, job.ID, info); !errors.Is(err, ErrNotFound) {
+		t.Errorf("song in the trash: error = %v, want %v", err, ErrNotFound)
+	}
+	if _, err = m.PurgeDeletedSongs(ctx, time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("failed to purge songs: %v", err)
+	}
+	if _, err = m.ReadJob(ctx, job.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("job of a purged song: error = %v, want %v", err, ErrNotFound)
+	}
+}
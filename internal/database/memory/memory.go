@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/database"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+var (
+	ErrNotFound        = database.ErrNotFound
+	ErrAlreadyExists   = database.ErrAlreadyExists
+	ErrGroupNotEmpty   = database.ErrGroupNotEmpty
+	ErrVersionMismatch = database.ErrVersionMismatch
+)
+
+// song mirrors a row of the songs table.
+type song struct {
+	id          int
+	title       string
+	groupID     int
+	releaseDate time.Time
+	text        string
+	link        string
+	lang        string
+	version     int
+	status      string
+	sources     models.Sources
+	enrichedAt  *time.Time
+	deletedAt   *time.Time
+}
+
+// Memory is a thread-safe in-memory implementation of database.Storage.
+// It mirrors the behaviour of the PostgreSQL storage and is meant for tests
+// and running the API locally without a database.
+type Memory struct {
+	mu           sync.RWMutex
+	songs        map[int]*song
+	groups       map[int]string
+	groupIDs     map[string]int
+	versions     map[int][]models.SongVersion
+	jobs         map[int]*job
+	sections     map[int]*models.SongSections
+	syncedLyrics map[int]*models.SyncedLyrics
+	chordCharts  map[int]*models.ChordChart
+	translations map[int]map[string]*translation
+	nextSongID   int
+	nextGroupID  int
+	nextJobID    int
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		songs:        make(map[int]*song),
+		groups:       make(map[int]string),
+		groupIDs:     make(map[string]int),
+		versions:     make(map[int][]models.SongVersion),
+		jobs:         make(map[int]*job),
+		sections:     make(map[int]*models.SongSections),
+		syncedLyrics: make(map[int]*models.SyncedLyrics),
+		chordCharts:  make(map[int]*models.ChordChart),
+		translations: make(map[int]map[string]*translation),
+		nextSongID:   1,
+		nextGroupID:  1,
+		nextJobID:    1,
+	}
+}
+
+func (m *Memory) Close() {}
+
+// groupID returns the id of the group with the given name, creating the
+// group if it doesn't exist yet. The caller must hold the write lock.
+func (m *Memory) groupID(name string) int {
+	if id, ok := m.groupIDs[name]; ok {
+		return id
+	}
+	id := m.nextGroupID
+	m.nextGroupID++
+	m.groups[id] = name
+	m.groupIDs[name] = id
+	return id
+}
+
+// sortedIDs returns the ids of all stored songs in insertion order.
+// The caller must hold at least the read lock.
+func (m *Memory) sortedIDs() []int {
+	ids := make([]int, 0, len(m.songs))
+	for id := range m.songs {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
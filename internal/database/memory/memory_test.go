@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// newTestMemory returns storage holding songs with the titles, all by Muse,
+// with IDs from 1 on.
+func newTestMemory(t *testing.T, titles ...string) *Memory {
+	t.Helper()
+	m := NewMemory()
+	t.Cleanup(m.Close)
+	for _, title := range titles {
+		if _, err := m.CreateSong(context.Background(), &models.Song{Title: title, Group: "Muse", Text: title + " lyrics"}); err != nil {
+			t.Fatalf("failed to create song: %v", err)
+		}
+	}
+	return m
+}
+
+func TestSongs(t *testing.T) {
+	m := newTestMemory(t, "Uprising", "Starlight")
+	ctx := context.Background()
+
+	song, err := m.ReadByID(ctx, 2)
+	if err != nil {
+		t.Fatalf("failed to read song: %v", err)
+	}
+	if song.Title != "Starlight" || song.Group != "Muse" || song.Text != "Starlight lyrics" {
+		t.Errorf("song = %+v", song)
+	}
+
+	song.Title = "Hysteria"
+	song.Group = "Muse "
+	if err = m.UpdateSong(ctx, song); err != nil {
+		t.Fatalf("failed to update song: %v", err)
+	}
+	if song, err = m.ReadByID(ctx, 2); err != nil || song.Title != "Hysteria" || song.Group != "Muse " {
+		t.Errorf("updated song = %+v, %v", song, err)
+	}
+	if _, err = m.ReadByID(ctx, 3); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing song: error = %v, want %v", err, ErrNotFound)
+	}
+	if err = m.UpdateSong(ctx, &models.Song{ID: 3, Title: "Knights of Cydonia"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("updating a missing song: error = %v, want %v", err, ErrNotFound)
+	}
+
+	songs, err := m.ReadFilteredSongs(ctx, &models.Filter{Groups: []string{"Muse"}})
+	if err != nil || len(songs) != 1 || songs[0].Title != "Uprising" {
+		t.Errorf("songs of Muse = %+v, %v", songs, err)
+	}
+
+	if err = m.DeleteSong(ctx, 1, 0); err != nil {
+		t.Fatalf("failed to delete song: %v", err)
+	}
+	if _, err = m.ReadByID(ctx, 1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("deleted song: error = %v, want %v", err, ErrNotFound)
+	}
+	if err = m.DeleteSong(ctx, 1, 0); !errors.Is(err, ErrNotFound) {
+		t.Errorf("deleting twice: error = %v, want %v", err, ErrNotFound)
+	}
+}
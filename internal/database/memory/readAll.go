@@ -0,0 +1,181 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+func (m *Memory) ReadFilteredSongs(_ context.Context, filter *models.Filter) ([]models.Song, error) {
+	// Валидация ввода
+	if filter.Limit < 0 {
+		return nil, fmt.Errorf("limit must be non-negative")
+	}
+	if filter.Offset < 0 {
+		return nil, fmt.Errorf("offset must be non-negative")
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	songs := m.match(filter)
+
+	if filter.Cursor != nil {
+		ordering := filter.Ordering()
+		after := make([]models.Song, 0, len(songs))
+		for i := range songs {
+			if compareToCursor(&songs[i], ordering, filter.Cursor) > 0 {
+				after = append(after, songs[i])
+			}
+		}
+		songs = after
+	}
+
+	return paginate(songs, filter.Limit, filter.Offset), nil
+}
+
+func (m *Memory) CountSongs(_ context.Context, filter *models.Filter) (int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.match(filter)), nil
+}
+
+// match returns all songs satisfying the filter conditions in result order,
+// ignoring pagination. The caller must hold at least the read lock.
+func (m *Memory) match(filter *models.Filter) []models.Song {
+	search := parseSearchQuery(filter.Query)
+	text := strings.ToLower(filter.Text)
+
+	var songs []models.Song
+
+	for _, id := range m.sortedIDs() {
+		s := m.songs[id]
+		if (s.deletedAt != nil) != filter.Deleted {
+			continue
+		}
+		if len(filter.Titles) > 0 && !matchAny(s.title, filter.Titles, filter.TitleMatch) {
+			continue
+		}
+		if len(filter.Groups) > 0 && !matchAny(m.groups[s.groupID], filter.Groups, filter.GroupMatch) {
+			continue
+		}
+		if filter.GroupID != 0 && s.groupID != filter.GroupID {
+			continue
+		}
+		if !filter.ReleaseDate.Equal(time.Time{}) && !s.releaseDate.Equal(filter.ReleaseDate) {
+			continue
+		}
+		if !filter.ReleaseDateFrom.IsZero() && s.releaseDate.Before(filter.ReleaseDateFrom) {
+			continue
+		}
+		if !filter.ReleaseDateTo.IsZero() && s.releaseDate.After(filter.ReleaseDateTo) {
+			continue
+		}
+		if filter.Year != 0 && s.releaseDate.Year() != filter.Year {
+			continue
+		}
+		if text != "" && !strings.Contains(strings.ToLower(s.text), text) {
+			continue
+		}
+		if filter.Link != "" && s.link != filter.Link {
+			continue
+		}
+
+		song := m.toModel(s)
+		if filter.Query != "" {
+			rank, ok := search.rank(s.title, s.text)
+			if !ok {
+				continue
+			}
+			song.Rank = rank
+			song.Snippet = search.headline(s.text)
+		}
+		songs = append(songs, *song)
+	}
+
+	ordering := filter.Ordering()
+	byRank := filter.Query != "" && len(filter.Sort) == 0
+	sort.SliceStable(songs, func(i, j int) bool {
+		if byRank && songs[i].Rank != songs[j].Rank {
+			return songs[i].Rank > songs[j].Rank
+		}
+		for _, field := range ordering {
+			c := compareField(&songs[i], field.Field, songs[j].SortValue(field.Field))
+			if c != 0 {
+				return (c < 0) != field.Desc
+			}
+		}
+		return false
+	})
+
+	return songs
+}
+
+// matchAny reports whether value matches any of the filter values.
+func matchAny(value string, values []string, match string) bool {
+	lower := strings.ToLower(value)
+	for _, v := range values {
+		switch match {
+		case models.MatchPrefix:
+			if strings.HasPrefix(lower, strings.ToLower(v)) {
+				return true
+			}
+		case models.MatchContains:
+			if strings.Contains(lower, strings.ToLower(v)) {
+				return true
+			}
+		default:
+			if value == v {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// compareToCursor reports whether the song comes before (-1), at (0) or
+// after (1) the cursor position in the ordering.
+func compareToCursor(song *models.Song, ordering []models.SortField, c *models.Cursor) int {
+	for i, field := range ordering {
+		if i >= len(c.Values) {
+			break
+		}
+		result := compareField(song, field.Field, c.Values[i])
+		if result != 0 {
+			if field.Desc {
+				return -result
+			}
+			return result
+		}
+	}
+	return 0
+}
+
+// compareField compares the sort value of the song with value, which is
+// formatted like models.Song.SortValue.
+func compareField(song *models.Song, field, value string) int {
+	if field == models.SortID {
+		id, _ := strconv.Atoi(value)
+		return cmp.Compare(song.ID, id)
+	}
+	return strings.Compare(song.SortValue(field), value)
+}
+
+// paginate applies limit and offset to songs, where a zero limit means no limit.
+func paginate(songs []models.Song, limit, offset int) []models.Song {
+	if offset >= len(songs) {
+		return nil
+	}
+	songs = songs[offset:]
+	if limit > 0 && limit < len(songs) {
+		songs = songs[:limit]
+	}
+	return songs
+}
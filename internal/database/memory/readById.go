@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+func (m *Memory) ReadByID(_ context.Context, id int) (*models.Song, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	s, ok := m.songs[id]
+	if !ok || s.deletedAt != nil {
+		return nil, ErrNotFound
+	}
+	return m.toModel(s), nil
+}
+
+// toModel converts a stored row into models.Song. The caller must hold at
+// least the read lock.
+func (m *Memory) toModel(s *song) *models.Song {
+	return &models.Song{
+		ID:          s.id,
+		Title:       s.title,
+		Group:       m.groups[s.groupID],
+		ReleaseDate: s.releaseDate,
+		Text:        s.text,
+		Link:        s.link,
+		Lang:        s.lang,
+		Version:     s.version,
+		Status:      s.status,
+		Sources:     s.sources,
+		EnrichedAt:  s.enrichedAt,
+		DeletedAt:   s.deletedAt,
+	}
+}
@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+func (m *Memory) RefreshSong(_ context.Context, s *models.Song) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.songs[s.ID]
+	if !ok || stored.deletedAt != nil {
+		return ErrNotFound
+	}
+	if s.Version != stored.version {
+		return ErrVersionMismatch
+	}
+	now := time.Now()
+	stored.releaseDate = s.ReleaseDate
+	stored.text = s.Text
+	stored.link = s.Link
+	stored.sources = s.Sources
+	stored.status = models.SongStatusReady
+	stored.enrichedAt = &now
+	stored.version++
+	s.Version = stored.version
+	s.EnrichedAt = &now
+	m.recordVersion(s.ID, models.OperationRefresh)
+	return nil
+}
+
+func (m *Memory) MarkSongRefreshed(_ context.Context, id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.songs[id]
+	if !ok || s.deletedAt != nil {
+		return ErrNotFound
+	}
+	now := time.Now()
+	s.enrichedAt = &now
+	return nil
+}
+
+func (m *Memory) ReadStaleSongs(_ context.Context, before, emptyBefore time.Time, limit int) ([]models.Song, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var stale []*song
+	for _, s := range m.songs {
+		if s.deletedAt != nil || s.status == models.SongStatusPending {
+			continue
+		}
+		empty := s.releaseDate.IsZero() || s.text == "" || s.link == ""
+		if s.enrichedAt == nil || s.enrichedAt.Before(before) || (empty && s.enrichedAt.Before(emptyBefore)) {
+			stale = append(stale, s)
+		}
+	}
+	sort.Slice(stale, func(i, j int) bool {
+		a, b := stale[i].enrichedAt, stale[j].enrichedAt
+		if a == nil || b == nil {
+			if a == nil && b == nil {
+				return stale[i].id < stale[j].id
+			}
+			return a == nil
+		}
+		if !a.Equal(*b) {
+			return a.Before(*b)
+		}
+		return stale[i].id < stale[j].id
+	})
+
+	songs := make([]models.Song, 0, min(len(stale), limit))
+	for _, s := range stale[:min(len(stale), limit)] {
+		songs = append(songs, *m.toModel(s))
+	}
+	return songs, nil
+}
@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReadStaleSongs(t *testing.T) {
+	m := newTestMemory(t, "Recent", "Old", "Never", "Recent empty", "Older empty", "Deleted", "Oldest")
+	ctx := context.Background()
+
+	now := time.Now()
+	at := func(ago time.Duration) *time.Time {
+		stamp := now.Add(-ago)
+		return &stamp
+	}
+	releaseDate := time.Date(2009, time.September, 7, 0, 0, 0, 0, time.UTC)
+	for _, s := range m.songs {
+		s.releaseDate = releaseDate
+		s.link = "https://example.com"
+	}
+	m.songs[1].enrichedAt = at(time.Hour)
+	m.songs[2].enrichedAt = at(48 * time.Hour)
+	m.songs[3].enrichedAt = nil
+	m.songs[4].enrichedAt = at(time.Hour)
+	m.songs[4].link = ""
+	m.songs[5].enrichedAt = at(12 * time.Hour)
+	m.songs[5].releaseDate = time.Time{}
+	m.songs[6].enrichedAt = nil
+	m.songs[6].deletedAt = at(time.Hour)
+	m.songs[7].enrichedAt = at(72 * time.Hour)
+	createPendingSongs(t, m, "Pending")
+
+	// Songs are stale after a day, and songs with empty fields after
+	// six hours.
+	before, emptyBefore := now.Add(-24*time.Hour), now.Add(-6*time.Hour)
+	tests := []struct {
+		name  string
+		limit int
+		want  []string
+	}{
+		{name: "all", limit: 10, want: []string{"Never", "Oldest", "Old", "Older empty"}},
+		{name: "limited", limit: 2, want: []string{"Never", "Oldest"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			songs, err := m.ReadStaleSongs(ctx, before, emptyBefore, tt.limit)
+			if err != nil {
+				t.Fatalf("failed to read stale songs: %v", err)
+			}
+			titles := make([]string, len(songs))
+			for i, song := range songs {
+				titles[i] = song.Title
+			}
+			if !reflect.DeepEqual(titles, tt.want) {
+				t.Errorf("titles = %q, want %q", titles, tt.want)
+			}
+		})
+	}
+}
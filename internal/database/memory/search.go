@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// searchQuery is a simplified counterpart of websearch_to_tsquery: every
+// term must occur in the song, while terms prefixed with '-' must not.
+// Terms match words by prefix, which roughly stands in for stemming.
+type searchQuery struct {
+	include []string
+	exclude []string
+}
+
+func parseSearchQuery(q string) searchQuery {
+	var sq searchQuery
+	for _, field := range strings.Fields(strings.ToLower(q)) {
+		negated := strings.HasPrefix(field, "-")
+		for _, term := range words(field) {
+			if term == "or" {
+				continue
+			}
+			if negated {
+				sq.exclude = append(sq.exclude, term)
+			} else {
+				sq.include = append(sq.include, term)
+			}
+		}
+	}
+	return sq
+}
+
+// rank reports whether the title and text match the query and returns a
+// relevance score, weighting title matches above lyrics matches.
+func (sq searchQuery) rank(title, text string) (float64, bool) {
+	if len(sq.include) == 0 {
+		return 0, false
+	}
+	titleWords, textWords := words(strings.ToLower(title)), words(strings.ToLower(text))
+
+	for _, term := range sq.exclude {
+		if countMatches(titleWords, term)+countMatches(textWords, term) > 0 {
+			return 0, false
+		}
+	}
+
+	var score float64
+	for _, term := range sq.include {
+		inTitle, inText := countMatches(titleWords, term), countMatches(textWords, term)
+		if inTitle+inText == 0 {
+			return 0, false
+		}
+		score += float64(inTitle) + 0.4*float64(inText)/float64(len(textWords)+1)
+	}
+	return score, true
+}
+
+// headline returns the lines of text containing query terms as HTML, with
+// the matched words wrapped into <b></b> like the snippets of PostgreSQL.
+func (sq searchQuery) headline(text string) string {
+	const maxLines = 2
+	var fragments []string
+
+	for _, line := range strings.Split(text, "\n") {
+		var b strings.Builder
+		matched := false
+		for _, token := range splitKeepingSeparators(line) {
+			lower := strings.ToLower(token)
+			hit := false
+			for _, term := range sq.include {
+				if strings.HasPrefix(lower, term) {
+					hit = true
+					break
+				}
+			}
+			if hit {
+				matched = true
+				b.WriteString("<b>" + html.EscapeString(token) + "</b>")
+			} else {
+				b.WriteString(html.EscapeString(token))
+			}
+		}
+		if matched {
+			fragments = append(fragments, b.String())
+			if len(fragments) == maxLines {
+				break
+			}
+		}
+	}
+	return strings.Join(fragments, " ... ")
+}
+
+func words(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func countMatches(words []string, term string) int {
+	n := 0
+	for _, w := range words {
+		if strings.HasPrefix(w, term) {
+			n++
+		}
+	}
+	return n
+}
+
+// splitKeepingSeparators splits s into alternating word and non-word tokens.
+func splitKeepingSeparators(s string) []string {
+	var tokens []string
+	start := 0
+	isWord := func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }
+	runes := []rune(s)
+	for i := 1; i <= len(runes); i++ {
+		if i == len(runes) || isWord(runes[i]) != isWord(runes[i-1]) {
+			tokens = append(tokens, string(runes[start:i]))
+			start = i
+		}
+	}
+	return tokens
+}
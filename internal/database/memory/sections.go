@@ -0,0 +1,34 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+func (m *Memory) ReadSongSections(_ context.Context, songID int) (*models.SongSections, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stored, ok := m.sections[songID]
+	if !ok {
+		return nil, nil
+	}
+	res := *stored
+	return &res, nil
+}
+
+func (m *Memory) SaveSongSections(_ context.Context, sections *models.SongSections) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.songs[sections.SongID]; !ok {
+		return ErrNotFound
+	}
+	if stored, ok := m.sections[sections.SongID]; ok && stored.Version > sections.Version {
+		return nil
+	}
+	res := *sections
+	m.sections[sections.SongID] = &res
+	return nil
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+func (m *Memory) ReadSyncedLyrics(_ context.Context, songID int) (*models.SyncedLyrics, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	s, ok := m.songs[songID]
+	if !ok || s.deletedAt != nil {
+		return nil, ErrNotFound
+	}
+	stored, ok := m.syncedLyrics[songID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	res := *stored
+	return &res, nil
+}
+
+func (m *Memory) SaveSyncedLyrics(_ context.Context, lyrics *models.SyncedLyrics) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.songs[lyrics.SongID]
+	if !ok || s.deletedAt != nil {
+		return ErrNotFound
+	}
+	lyrics.UpdatedAt = time.Now()
+	res := *lyrics
+	m.syncedLyrics[lyrics.SongID] = &res
+	return nil
+}
+
+func (m *Memory) DeleteSyncedLyrics(_ context.Context, songID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.songs[songID]
+	if !ok || s.deletedAt != nil {
+		return ErrNotFound
+	}
+	if _, ok = m.syncedLyrics[songID]; !ok {
+		return ErrNotFound
+	}
+	delete(m.syncedLyrics, songID)
+	return nil
+}
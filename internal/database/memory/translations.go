@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// translation mirrors a row of the song_translations table.
+type translation struct {
+	models.Translation
+	songText string // Text of the song the translation was saved for.
+}
+
+func (m *Memory) ReadTranslations(_ context.Context, songID int) ([]models.Translation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	s, ok := m.songs[songID]
+	if !ok || s.deletedAt != nil {
+		return nil, ErrNotFound
+	}
+	translations := make([]models.Translation, 0, len(m.translations[songID]))
+	for _, stored := range m.translations[songID] {
+		translation := stored.Translation
+		translation.Outdated = stored.songText != s.text
+		translations = append(translations, translation)
+	}
+	sort.Slice(translations, func(i, j int) bool { return translations[i].Lang < translations[j].Lang })
+	return translations, nil
+}
+
+func (m *Memory) ReadTranslation(_ context.Context, songID int, lang string) (*models.Translation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	s, ok := m.songs[songID]
+	if !ok || s.deletedAt != nil {
+		return nil, ErrNotFound
+	}
+	stored, ok := m.translations[songID][lang]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	res := stored.Translation
+	res.Outdated = stored.songText != s.text
+	return &res, nil
+}
+
+func (m *Memory) SaveTranslation(_ context.Context, t *models.Translation) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.songs[t.SongID]
+	if !ok || s.deletedAt != nil {
+		return false, ErrNotFound
+	}
+	if m.translations[t.SongID] == nil {
+		m.translations[t.SongID] = make(map[string]*translation)
+	}
+	t.Version = 1
+	stored, exists := m.translations[t.SongID][t.Lang]
+	if exists {
+		t.Version = stored.Version + 1
+	}
+	t.UpdatedAt = time.Now()
+	m.translations[t.SongID][t.Lang] = &translation{Translation: *t, songText: s.text}
+	return !exists, nil
+}
+
+func (m *Memory) DeleteTranslation(_ context.Context, songID int, lang string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.songs[songID]
+	if !ok || s.deletedAt != nil {
+		return ErrNotFound
+	}
+	if _, ok = m.translations[songID][lang]; !ok {
+		return ErrNotFound
+	}
+	delete(m.translations[songID], lang)
+	return nil
+}
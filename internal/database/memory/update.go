@@ -0,0 +1,31 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+func (m *Memory) UpdateSong(_ context.Context, s *models.Song) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.songs[s.ID]
+	if !ok || stored.deletedAt != nil {
+		return ErrNotFound
+	}
+	if s.Version != 0 && s.Version != stored.version {
+		return ErrVersionMismatch
+	}
+	stored.title = s.Title
+	stored.groupID = m.groupID(s.Group)
+	stored.releaseDate = s.ReleaseDate
+	stored.text = s.Text
+	stored.link = s.Link
+	stored.sources = s.Sources
+	stored.lang = s.Lang
+	stored.version++
+	s.Version = stored.version
+	m.recordVersion(s.ID, models.OperationUpdate)
+	return nil
+}
@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// recordVersion appends the current state of the song to its history.
+// The caller must hold the write lock.
+func (m *Memory) recordVersion(id int, operation string) {
+	s, ok := m.songs[id]
+	if !ok {
+		return
+	}
+	song := m.toModel(s)
+	song.DeletedAt = nil
+	song.Lang = ""
+	song.Version = 0
+	song.Status = ""
+	song.Sources = nil
+	song.EnrichedAt = nil
+	m.versions[id] = append(m.versions[id], models.SongVersion{
+		Version:   len(m.versions[id]) + 1,
+		Operation: operation,
+		CreatedAt: time.Now(),
+		Song:      *song,
+	})
+}
+
+func (m *Memory) ReadSongVersions(_ context.Context, songID int) ([]models.SongVersion, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	versions, ok := m.versions[songID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]models.SongVersion(nil), versions...), nil
+}
+
+func (m *Memory) ReadSongVersion(_ context.Context, songID, version int) (*models.SongVersion, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.version(songID, version)
+}
+
+// version returns a copy of the song version. The caller must hold at least
+// the read lock.
+func (m *Memory) version(songID, version int) (*models.SongVersion, error) {
+	versions := m.versions[songID]
+	if version < 1 || version > len(versions) {
+		return nil, ErrNotFound
+	}
+	v := versions[version-1]
+	return &v, nil
+}
+
+func (m *Memory) RestoreSongVersion(_ context.Context, songID, version int) (*models.Song, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	v, err := m.version(songID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	// A song in the trash is taken out of it, and a song that has been purged
+	// since is recreated with its old id.
+	stored, ok := m.songs[songID]
+	if !ok {
+		// The version goes on from the history, which outlives the song.
+		stored = &song{id: songID, status: models.SongStatusReady, version: len(m.versions[songID])}
+		m.songs[songID] = stored
+	}
+	stored.version++
+	stored.title = v.Song.Title
+	stored.groupID = m.groupID(v.Song.Group)
+	stored.releaseDate = v.Song.ReleaseDate
+	stored.text = v.Song.Text
+	stored.link = v.Song.Link
+	stored.sources = nil
+	stored.deletedAt = nil
+	m.recordVersion(songID, models.OperationRestore)
+
+	return m.toModel(stored), nil
+}
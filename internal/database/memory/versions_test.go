@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+func TestRestoreSongVersion(t *testing.T) {
+	m := newTestMemory(t, "Uprising")
+	ctx := context.Background()
+
+	song, err := m.ReadByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to read song: %v", err)
+	}
+	song.Title = "Hysteria"
+	if err = m.UpdateSong(ctx, song); err != nil {
+		t.Fatalf("failed to update song: %v", err)
+	}
+
+	steps := []struct {
+		name        string
+		change      func() error
+		version     int
+		wantTitle   string
+		wantVersion int
+	}{
+		{name: "updated song", version: 1, wantTitle: "Uprising", wantVersion: 3},
+		{
+			name:        "song in the trash",
+			change:      func() error { return m.DeleteSong(ctx, 1, 0) },
+			version:     2,
+			wantTitle:   "Hysteria",
+			wantVersion: 5,
+		},
+		{
+			name: "purged song",
+			change: func() error {
+				if err := m.DeleteSong(ctx, 1, 0); err != nil {
+					return err
+				}
+				_, err := m.PurgeDeletedSongs(ctx, time.Now().Add(time.Minute))
+				return err
+			},
+			version:     1,
+			wantTitle:   "Uprising",
+			wantVersion: 7,
+		},
+	}
+	for _, step := range steps {
+		if step.change != nil {
+			if err := step.change(); err != nil {
+				t.Fatalf("%s: unexpected error: %v", step.name, err)
+			}
+		}
+		restored, err := m.RestoreSongVersion(ctx, 1, step.version)
+		if err != nil {
+			t.Fatalf("%s: failed to restore version: %v", step.name, err)
+		}
+		if restored.Title != step.wantTitle || restored.Version != step.wantVersion || restored.DeletedAt != nil {
+			t.Errorf("%s: restored song = %+v, want %q at version %d", step.name, restored, step.wantTitle, step.wantVersion)
+		}
+		if song, err := m.ReadByID(ctx, 1); err != nil || song.Version != step.wantVersion {
+			t.Errorf("%s: song = %+v, %v", step.name, song, err)
+		}
+		versions, err := m.ReadSongVersions(ctx, 1)
+		if err != nil {
+			t.Fatalf("%s: failed to read versions: %v", step.name, err)
+		}
+		last := versions[len(versions)-1]
+		if len(versions) != step.wantVersion || last.Version != step.wantVersion || last.Operation != models.OperationRestore {
+			t.Errorf("%s: %d versions, the last one %d by %q", step.name, len(versions), last.Version, last.Operation)
+		}
+	}
+
+	if _, err = m.RestoreSongVersion(ctx, 1, 8); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing version: error = %v, want %v", err, ErrNotFound)
+	}
+	if _, err = m.RestoreSongVersion(ctx, 2, 1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing song: error = %v, want %v", err, ErrNotFound)
+	}
+}
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS groups_name_key;
\ No newline at end of file
@@ -0,0 +1,14 @@
+-- Point songs of duplicated groups at the oldest group with the same name.
+UPDATE songs s
+SET group_id = d.keep_id
+FROM (
+    SELECT id, MIN(id) OVER (PARTITION BY name) AS keep_id
+    FROM groups
+) d
+WHERE s.group_id = d.id AND d.id <> d.keep_id;
+
+DELETE FROM groups g
+USING groups k
+WHERE g.name = k.name AND g.id > k.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS groups_name_key ON groups(name);
@@ -0,0 +1,9 @@
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', title), 'A') ||
+        setweight(to_tsvector('english', title), 'A') ||
+        setweight(to_tsvector('russian', song_text), 'B') ||
+        setweight(to_tsvector('english', song_text), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS songs_search_vector_idx ON songs USING GIN (search_vector);
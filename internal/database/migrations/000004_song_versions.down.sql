@@ -0,0 +1 @@
+DROP TABLE IF EXISTS song_versions;
\ No newline at end of file
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS song_versions (
+    id SERIAL PRIMARY KEY,
+    song_id INTEGER NOT NULL,
+    version INTEGER NOT NULL,
+    operation TEXT NOT NULL,
+    title TEXT NOT NULL,
+    group_name TEXT NOT NULL,
+    release_date DATE NOT NULL,
+    song_text TEXT NOT NULL,
+    link TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (song_id, version)
+);
+
+-- Existing songs start their history with their current state.
+INSERT INTO song_versions (song_id, version, operation, title, group_name, release_date, song_text, link)
+SELECT s.id, 1, 'create', s.title, g.name, s.release_date, s.song_text, s.link
+FROM songs s JOIN groups g ON g.id = s.group_id
+ON CONFLICT (song_id, version) DO NOTHING;
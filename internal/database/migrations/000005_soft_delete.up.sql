@@ -0,0 +1,3 @@
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS songs_deleted_at_idx ON songs(deleted_at) WHERE deleted_at IS NOT NULL;
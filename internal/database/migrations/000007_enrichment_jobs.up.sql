@@ -0,0 +1,16 @@
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'ready';
+
+CREATE TABLE IF NOT EXISTS enrichment_jobs (
+    id SERIAL PRIMARY KEY,
+    song_id INTEGER NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'queued',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    next_run_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    locked_until TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS enrichment_jobs_next_run_at_idx ON enrichment_jobs(next_run_at)
+    WHERE status IN ('queued', 'running');
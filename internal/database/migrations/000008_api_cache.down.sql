@@ -0,0 +1 @@
+DROP TABLE IF EXISTS api_cache;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS api_cache (
+    key TEXT PRIMARY KEY,
+    not_found BOOLEAN NOT NULL DEFAULT FALSE,
+    release_date DATE NOT NULL,
+    song_text TEXT NOT NULL,
+    link TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS api_cache_expires_at_idx ON api_cache(expires_at);
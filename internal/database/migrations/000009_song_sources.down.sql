@@ -0,0 +1,3 @@
+ALTER TABLE api_cache DROP COLUMN IF EXISTS sources;
+
+ALTER TABLE songs DROP COLUMN IF EXISTS sources;
\ No newline at end of file
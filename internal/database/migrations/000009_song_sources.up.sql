@@ -0,0 +1,3 @@
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS sources JSONB NOT NULL DEFAULT '{}';
+
+ALTER TABLE api_cache ADD COLUMN IF NOT EXISTS sources JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1 @@
+ALTER TABLE songs DROP COLUMN IF EXISTS enriched_at;
\ No newline at end of file
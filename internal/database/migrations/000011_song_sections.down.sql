@@ -0,0 +1 @@
+DROP TABLE IF EXISTS song_sections;
\ No newline at end of file
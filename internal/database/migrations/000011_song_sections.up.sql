@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS song_sections (
+    song_id INTEGER PRIMARY KEY REFERENCES songs(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    sections JSONB NOT NULL,
+    parsed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
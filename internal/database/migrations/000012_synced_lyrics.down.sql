@@ -0,0 +1 @@
+DROP TABLE IF EXISTS synced_lyrics;
\ No newline at end of file
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS synced_lyrics (
+    song_id INTEGER PRIMARY KEY REFERENCES songs(id) ON DELETE CASCADE,
+    lines JSONB NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS chord_charts;
\ No newline at end of file
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS chord_charts (
+    song_id INTEGER PRIMARY KEY REFERENCES songs(id) ON DELETE CASCADE,
+    source TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,12 @@
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS lang TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS song_translations (
+    song_id INTEGER NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    lang TEXT NOT NULL,
+    text TEXT NOT NULL,
+    version INTEGER NOT NULL DEFAULT 1,
+    song_version INTEGER NOT NULL,
+    song_text_hash TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (song_id, lang)
+);
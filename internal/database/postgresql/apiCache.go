@@ -0,0 +1,59 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// ReadCachedSongInfo returns the cached answer of the song info API, or nil if
+// there is none or it has expired.
+func (p PostgreSQL) ReadCachedSongInfo(ctx context.Context, key string) (*models.CachedSongInfo, error) {
+	const op = "postgresql.ReadCachedSongInfo"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	info := models.CachedSongInfo{Key: key}
+
+	query := `SELECT not_found, release_date, song_text, link, sources, expires_at
+		FROM api_cache
+		WHERE key=$1 AND expires_at > now();`
+	err := p.pool.QueryRow(ctx, query, &key).Scan(&info.NotFound, &info.ReleaseDate, &info.Text,
+		&info.Link, &info.Sources, &info.ExpiresAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &info, nil
+}
+
+// SaveCachedSongInfo stores the answer of the song info API, replacing the
+// previous one. Expired answers are removed along the way.
+func (p PostgreSQL) SaveCachedSongInfo(ctx context.Context, info *models.CachedSongInfo) error {
+	const op = "postgresql.SaveCachedSongInfo"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	err := p.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		query := `INSERT INTO api_cache (key, not_found, release_date, song_text, link, sources, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (key) DO UPDATE SET not_found=EXCLUDED.not_found, release_date=EXCLUDED.release_date,
+				song_text=EXCLUDED.song_text, link=EXCLUDED.link, sources=EXCLUDED.sources, expires_at=EXCLUDED.expires_at;`
+		_, err := tx.Exec(ctx, query, &info.Key, &info.NotFound, &info.ReleaseDate, &info.Text,
+			&info.Link, sources(info.Sources), &info.ExpiresAt)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, "DELETE FROM api_cache WHERE expires_at <= now();")
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
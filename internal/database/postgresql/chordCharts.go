@@ -0,0 +1,66 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+func (p PostgreSQL) ReadChordChart(ctx context.Context, songID int) (*models.ChordChart, error) {
+	const op = "postgresql.ReadChordChart"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	chart := models.ChordChart{SongID: songID}
+
+	query := `SELECT c.source, c.updated_at
+		FROM chord_charts c JOIN songs s ON s.id = c.song_id
+		WHERE c.song_id=$1 AND s.deleted_at IS NULL;`
+	err := p.pool.QueryRow(ctx, query, &songID).Scan(&chart.Source, &chart.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &chart, nil
+}
+
+// SaveChordChart attaches the chord chart to the song, replacing the
+// previous one. On success chart.UpdatedAt holds the time of the change.
+func (p PostgreSQL) SaveChordChart(ctx context.Context, chart *models.ChordChart) error {
+	const op = "postgresql.SaveChordChart"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	query := `INSERT INTO chord_charts (song_id, source)
+		SELECT id, $2 FROM songs WHERE id=$1 AND deleted_at IS NULL
+		ON CONFLICT (song_id) DO UPDATE SET source=EXCLUDED.source, updated_at=now()
+		RETURNING updated_at;`
+	err := p.pool.QueryRow(ctx, query, &chart.SongID, &chart.Source).Scan(&chart.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return ErrNotFound
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (p PostgreSQL) DeleteChordChart(ctx context.Context, songID int) error {
+	const op = "postgresql.DeleteChordChart"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	query := `DELETE FROM chord_charts c USING songs s
+		WHERE c.song_id=$1 AND s.id = c.song_id AND s.deleted_at IS NULL;`
+	commandTag, err := p.pool.Exec(ctx, query, &songID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
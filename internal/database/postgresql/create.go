@@ -3,40 +3,34 @@ package postgresql
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/jackc/pgx/v4"
 	"github.com/notblinkyet/song-library-api/internal/models"
 )
 
-func (p PostgreSQL) CreateSong(song *models.Song) (int, error) {
+func (p PostgreSQL) CreateSong(ctx context.Context, song *models.Song) (int, error) {
 	const op = "postgresql.CreateSong"
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
-	var id, groupID int
+	var id int
 
-	query := `
-		SELECT id from groups WHERE name = $1
-	`
+	err := p.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		groupID, err := groupID(ctx, tx, song.Group)
+		if err != nil {
+			return err
+		}
 
-	err := p.pool.QueryRow(ctx, query, &song.Group).Scan(&groupID)
+		query := `INSERT INTO songs (title, group_id, release_date, song_text, link, sources, enriched_at)
+			VALUES ($1, $2, $3, $4, $5, $6, now()) RETURNING id;`
 
-	if err == pgx.ErrNoRows {
-		query = `
-			INSERT INTO groups(name)
-			VALUES($1) RETURNING id;
-		`
-		err = p.pool.QueryRow(ctx, query, &song.Group).Scan(&groupID)
+		err = tx.QueryRow(ctx, query, &song.Title, &groupID, &song.ReleaseDate, &song.Text, &song.Link,
+			sources(song.Sources)).Scan(&id)
 		if err != nil {
-			return 0, fmt.Errorf("%s: %w", op, err)
+			return err
 		}
-	} else if err != nil {
-		return 0, fmt.Errorf("%s: %w", op, err)
-	}
-
-	query = "INSERT INTO songs (title, group_id, release_date, song_text, link) VALUES ($1, $2, $3, $4, $5) RETURNING id;"
 
-	err = p.pool.QueryRow(ctx, query, &song.Title, &groupID, &song.ReleaseDate, &song.Text, &song.Link).Scan(&id)
+		return recordVersion(ctx, tx, id, models.OperationCreate)
+	})
 
 	if err != nil {
 		return 0, fmt.Errorf("%s:%w", op, err)
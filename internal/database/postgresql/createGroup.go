@@ -0,0 +1,24 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+)
+
+func (p PostgreSQL) CreateGroup(ctx context.Context, name string) (int, error) {
+	const op = "postgresql.CreateGroup"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	var id int
+
+	query := "INSERT INTO groups(name) VALUES($1) RETURNING id;"
+
+	err := p.pool.QueryRow(ctx, query, &name).Scan(&id)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, ErrAlreadyExists
+		}
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return id, nil
+}
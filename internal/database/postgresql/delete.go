@@ -4,23 +4,89 @@ import (
 	"context"
 	"fmt"
 	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/notblinkyet/song-library-api/internal/models"
 )
 
-func (p PostgreSQL) DeleteSong(id int) error {
+// DeleteSong moves the song to the trash. It stays there until it is
+// restored or purged by PurgeDeletedSongs. A non-zero version makes the
+// delete conditional, like in UpdateSong.
+func (p PostgreSQL) DeleteSong(ctx context.Context, id, version int) error {
 	const op = "postgresql.DeleteSong"
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
 
-	query := "DELETE FROM songs WHERE id = $1;"
+	err := p.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		query := `UPDATE songs SET deleted_at = now(), version = version + 1
+			WHERE id = $1 AND deleted_at IS NULL AND ($2 = 0 OR version = $2);`
+
+		commandTag, err := tx.Exec(ctx, query, &id, &version)
+		if err != nil {
+			return err
+		}
+
+		if commandTag.RowsAffected() == 0 {
+			return versionConflict(ctx, tx, id)
+		}
+
+		return recordVersion(ctx, tx, id, models.OperationDelete)
+	})
 
-	commandTag, err := p.pool.Exec(ctx, query, &id)
 	if err != nil {
+		if err == ErrNotFound || err == ErrVersionMismatch {
+			return err
+		}
 		return fmt.Errorf("%s:%w", op, err)
 	}
 
-	if commandTag.RowsAffected() == 0 {
-		return ErrNotFound
+	return nil
+}
+
+// RestoreSong takes the song out of the trash.
+func (p PostgreSQL) RestoreSong(ctx context.Context, id int) error {
+	const op = "postgresql.RestoreSong"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	err := p.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		query := "UPDATE songs SET deleted_at = NULL, version = version + 1 WHERE id = $1 AND deleted_at IS NOT NULL;"
+
+		commandTag, err := tx.Exec(ctx, query, &id)
+		if err != nil {
+			return err
+		}
+
+		if commandTag.RowsAffected() == 0 {
+			return ErrNotFound
+		}
+
+		return recordVersion(ctx, tx, id, models.OperationRestore)
+	})
+
+	if err != nil {
+		if err == ErrNotFound {
+			return ErrNotFound
+		}
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	return nil
 }
+
+// PurgeDeletedSongs permanently removes the songs that were moved to the
+// trash before the given time and returns how many were removed.
+func (p PostgreSQL) PurgeDeletedSongs(ctx context.Context, before time.Time) (int, error) {
+	const op = "postgresql.PurgeDeletedSongs"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	query := "DELETE FROM songs WHERE deleted_at < $1;"
+
+	commandTag, err := p.pool.Exec(ctx, query, &before)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return int(commandTag.RowsAffected()), nil
+}
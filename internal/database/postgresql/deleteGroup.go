@@ -0,0 +1,35 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+)
+
+func (p PostgreSQL) DeleteGroup(ctx context.Context, id int) error {
+	const op = "postgresql.DeleteGroup"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	query := `DELETE FROM groups g
+		WHERE g.id = $1 AND NOT EXISTS (SELECT 1 FROM songs s WHERE s.group_id = g.id);
+	`
+
+	commandTag, err := p.pool.Exec(ctx, query, &id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		var exists bool
+		err = p.pool.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM groups WHERE id = $1);", &id).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if exists {
+			return ErrGroupNotEmpty
+		}
+		return ErrNotFound
+	}
+
+	return nil
+}
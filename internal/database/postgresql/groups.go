@@ -0,0 +1,27 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+)
+
+// groupID returns the id of the group with the given name, creating the group
+// if it doesn't exist yet. Relies on the unique index on groups.name, so
+// concurrent calls never produce duplicate groups.
+func groupID(ctx context.Context, q querier, name string) (int, error) {
+	const op = "postgresql.groupID"
+	var id int
+
+	query := `
+		INSERT INTO groups(name)
+		VALUES($1)
+		ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+		RETURNING id;
+	`
+
+	err := q.QueryRow(ctx, query, &name).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return id, nil
+}
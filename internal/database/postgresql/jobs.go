@@ -0,0 +1,208 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+const jobColumns = `id, song_id, status, attempts, last_error, next_run_at, created_at, updated_at`
+
+func scanJob(row pgx.Row, job *models.Job) error {
+	return row.Scan(&job.ID, &job.SongID, &job.Status, &job.Attempts, &job.LastError,
+		&job.NextRunAt, &job.CreatedAt, &job.UpdatedAt)
+}
+
+// CreatePendingSong stores the song with the pending status together with a
+// job that fetches its details.
+func (p PostgreSQL) CreatePendingSong(ctx context.Context, song *models.Song) (*models.Job, error) {
+	const op = "postgresql.CreatePendingSong"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	var job models.Job
+
+	err := p.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		groupID, err := groupID(ctx, tx, song.Group)
+		if err != nil {
+			return err
+		}
+
+		var id int
+		query := `INSERT INTO songs (title, group_id, release_date, song_text, link, status)
+			VALUES ($1, $2, $3, $4, $5, $6) RETURNING id;`
+		status := models.SongStatusPending
+		err = tx.QueryRow(ctx, query, &song.Title, &groupID, &song.ReleaseDate, &song.Text, &song.Link,
+			&status).Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		if err = recordVersion(ctx, tx, id, models.OperationCreate); err != nil {
+			return err
+		}
+
+		query = "INSERT INTO enrichment_jobs (song_id) VALUES ($1) RETURNING " + jobColumns + ";"
+		return scanJob(tx.QueryRow(ctx, query, &id), &job)
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &job, nil
+}
+
+func (p PostgreSQL) ReadJob(ctx context.Context, id int) (*models.Job, error) {
+	const op = "postgresql.ReadJob"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	var job models.Job
+
+	query := "SELECT " + jobColumns + " FROM enrichment_jobs WHERE id=$1;"
+	err := scanJob(p.pool.QueryRow(ctx, query, &id), &job)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &job, nil
+}
+
+// ClaimJob takes the job that is due first and marks it as running for the
+// lease. A running job whose lease has expired, e.g. because its worker
+// crashed, is due again. Jobs of songs in the trash wait until the song is
+// restored. Concurrent workers never claim the same job. It returns nil if no
+// job is due.
+func (p PostgreSQL) ClaimJob(ctx context.Context, lease time.Duration) (*models.Job, error) {
+	const op = "postgresql.ClaimJob"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	var job models.Job
+
+	query := `UPDATE enrichment_jobs
+		SET status='running', attempts=attempts+1, locked_until=now() + $1 * interval '1 millisecond', updated_at=now()
+		WHERE id = (
+			SELECT id FROM enrichment_jobs
+			WHERE ((status='queued' AND next_run_at <= now()) OR (status='running' AND locked_until < now()))
+				AND EXISTS (SELECT 1 FROM songs s WHERE s.id = song_id AND s.deleted_at IS NULL)
+			ORDER BY next_run_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + jobColumns + ";"
+
+	leaseMs := lease.Milliseconds()
+	err := scanJob(p.pool.QueryRow(ctx, query, &leaseMs), &job)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &job, nil
+}
+
+// CompleteJob fills in the details of the song that are still empty, marks
+// the song as ready and the job as succeeded. It returns ErrNotFound if the
+// song has been deleted in the meantime.
+func (p PostgreSQL) CompleteJob(ctx context.Context, id int, info *models.Song) error {
+	const op = "postgresql.CompleteJob"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	err := p.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		var songID int
+		query := `UPDATE enrichment_jobs SET status='succeeded', last_error='', locked_until=NULL, updated_at=now()
+			WHERE id=$1 RETURNING song_id;`
+		err := tx.QueryRow(ctx, query, &id).Scan(&songID)
+		if err == pgx.ErrNoRows {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		// Changes made to the song while it was pending take precedence.
+		query = `UPDATE songs SET
+				release_date = CASE WHEN release_date = '0001-01-01' THEN $2 ELSE release_date END,
+				song_text = CASE WHEN song_text = '' THEN $3 ELSE song_text END,
+				link = CASE WHEN link = '' THEN $4 ELSE link END,
+				sources = sources || jsonb_strip_nulls(jsonb_build_object(
+					'releaseDate', CASE WHEN release_date = '0001-01-01' THEN $5::jsonb->'releaseDate' END,
+					'text', CASE WHEN song_text = '' THEN $5::jsonb->'text' END,
+					'link', CASE WHEN link = '' THEN $5::jsonb->'link' END)),
+				status = 'ready', enriched_at = now(), version = version+1
+			WHERE id=$1 AND deleted_at IS NULL;`
+		commandTag, err := tx.Exec(ctx, query, &songID, &info.ReleaseDate, &info.Text, &info.Link,
+			sources(info.Sources))
+		if err != nil {
+			return err
+		}
+		if commandTag.RowsAffected() == 0 {
+			return ErrNotFound
+		}
+
+		return recordVersion(ctx, tx, songID, models.OperationEnrich)
+	})
+
+	if err != nil {
+		if err == ErrNotFound {
+			return err
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// RetryJob puts the job back into the queue to run again at the given time.
+func (p PostgreSQL) RetryJob(ctx context.Context, id int, lastError string, at time.Time) error {
+	const op = "postgresql.RetryJob"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	query := `UPDATE enrichment_jobs SET status='queued', last_error=$2, next_run_at=$3, locked_until=NULL, updated_at=now()
+		WHERE id=$1;`
+	commandTag, err := p.pool.Exec(ctx, query, &id, &lastError, &at)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// FailJob gives up on the job and marks its song as failed.
+func (p PostgreSQL) FailJob(ctx context.Context, id int, lastError string) error {
+	const op = "postgresql.FailJob"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	err := p.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		var songID int
+		query := `UPDATE enrichment_jobs SET status='failed', last_error=$2, locked_until=NULL, updated_at=now()
+			WHERE id=$1 RETURNING song_id;`
+		err := tx.QueryRow(ctx, query, &id, &lastError).Scan(&songID)
+		if err == pgx.ErrNoRows {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		query = "UPDATE songs SET status='failed' WHERE id=$1 AND status='pending';"
+		_, err = tx.Exec(ctx, query, &songID)
+		return err
+	})
+
+	if err != nil {
+		if err == ErrNotFound {
+			return err
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
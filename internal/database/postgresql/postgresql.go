@@ -6,22 +6,37 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/notblinkyet/song-library-api/internal/config"
+	"github.com/notblinkyet/song-library-api/internal/database"
+	"github.com/notblinkyet/song-library-api/internal/models"
 )
 
 var (
-	ErrNoAffectedRows = errors.New("no affected row")
-	ErrNotFound       = errors.New("not found")
+	ErrNoAffectedRows  = errors.New("no affected row")
+	ErrNotFound        = database.ErrNotFound
+	ErrAlreadyExists   = database.ErrAlreadyExists
+	ErrGroupNotEmpty   = database.ErrGroupNotEmpty
+	ErrVersionMismatch = database.ErrVersionMismatch
 )
 
+// querier is implemented by both the pool and transactions, so helpers can
+// run inside and outside of a transaction.
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
 type PostgreSQL struct {
-	pool *pgxpool.Pool
+	pool    *pgxpool.Pool
+	timeout time.Duration
 }
 
 func NewPostgreSQL(config *config.Config) (*PostgreSQL, error) {
 	const op = "postgresql.New"
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), config.DbTimeout)
 	defer cancel()
 
 	connString := fmt.Sprintf("postgresql://%s:%s@%s:%d/%s", config.DbUser, config.DbPassword,
@@ -31,10 +46,26 @@ func NewPostgreSQL(config *config.Config) (*PostgreSQL, error) {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	return &PostgreSQL{
-		pool: pool,
+		pool:    pool,
+		timeout: config.DbTimeout,
 	}, nil
 }
 
 func (p *PostgreSQL) Close() {
 	p.pool.Close()
 }
+
+// sources returns the sources of a song ready to be stored in a JSONB
+// column, which holds an empty object rather than null when there are none.
+func sources(s models.Sources) models.Sources {
+	if s == nil {
+		return models.Sources{}
+	}
+	return s
+}
+
+// isUniqueViolation reports whether err was caused by a unique constraint.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
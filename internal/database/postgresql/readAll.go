@@ -3,6 +3,7 @@ package postgresql
 import (
 	"context"
 	"fmt"
+	"html"
 	"strconv"
 	"strings"
 	"time"
@@ -10,9 +11,18 @@ import (
 	"github.com/notblinkyet/song-library-api/internal/models"
 )
 
-func (p PostgreSQL) ReadFilteredSongs(filter *models.Filter) ([]models.Song, error) {
+// headlineOptions configures the snippets built by ts_headline for search
+// results. ts_headline doesn't escape the text, so matches are marked with
+// characters from the private use area, replaced with <b></b> once the text
+// has been escaped.
+const headlineOptions = "StartSel=\uE000, StopSel=\uE001, MaxWords=20, MinWords=5, MaxFragments=2"
+
+// headlineMarkup turns a snippet built with headlineOptions into HTML.
+var headlineMarkup = strings.NewReplacer("\uE000", "<b>", "\uE001", "</b>")
+
+func (p PostgreSQL) ReadFilteredSongs(ctx context.Context, filter *models.Filter) ([]models.Song, error) {
 	const op = "postgresql.ReadFilteredSongs"
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
 
 	// Валидация ввода
@@ -23,53 +33,32 @@ func (p PostgreSQL) ReadFilteredSongs(filter *models.Filter) ([]models.Song, err
 		return nil, fmt.Errorf("offset must be non-negative")
 	}
 
-	var query strings.Builder
-	query.WriteString("SELECT s.id, s.title, g.name, s.release_date, s.song_text, s.link FROM songs s JOIN groups g ON s.group_id=g.id")
-
 	args := make([]any, 0)
-	whereClauses := make([]string, 0, 5)
-	varCount := 1
+	whereClauses := p.whereClauses(filter, &args)
 
-	if filter.Title != "" {
-		whereClauses = append(whereClauses, fmt.Sprintf("title = $%d", varCount))
-		args = append(args, &filter.Title)
-		varCount++
-	}
+	var query strings.Builder
+	query.WriteString("SELECT s.id, s.title, g.name, s.release_date, s.song_text, s.link, s.lang, s.version, s.status, s.sources, s.enriched_at, s.deleted_at")
 
-	if filter.Group != "" {
-		var group_id int
-		q := `SELECT id FROM groups WHERE name=$1`
-		err := p.pool.QueryRow(ctx, q, &filter.Group).Scan(&group_id)
-		if err != nil {
-			return nil, fmt.Errorf("group not found: %s", filter.Group)
-		}
-		whereClauses = append(whereClauses, fmt.Sprintf("group_id = $%d", varCount))
-		args = append(args, &group_id)
-		varCount++
+	// whereClauses always binds the search query first, so it is $1 here.
+	if filter.Query != "" {
+		fmt.Fprintf(&query, ", ts_rank(s.search_vector, %s)::float8 AS rank", tsQuery(1))
+		fmt.Fprintf(&query, ", ts_headline('russian', s.song_text, %s, '%s')", tsQuery(1), headlineOptions)
 	}
 
-	if !filter.ReleaseDate.Equal(time.Time{}) {
-		whereClauses = append(whereClauses, fmt.Sprintf("release_date = $%d", varCount))
-		args = append(args, &filter.ReleaseDate)
-		varCount++
-	}
+	query.WriteString(" FROM songs s JOIN groups g ON s.group_id=g.id")
 
-	if filter.Text != "" {
-		whereClauses = append(whereClauses, fmt.Sprintf("song_text LIKE $%d", varCount))
-		args = append(args, &filter.Text)
-		varCount++
+	if filter.Cursor != nil {
+		whereClauses = append(whereClauses, keysetClause(filter.Ordering(), filter.Cursor, &args))
 	}
 
-	if filter.Link != "" {
-		whereClauses = append(whereClauses, fmt.Sprintf("link = $%d", varCount))
-		args = append(args, &filter.Link)
-		varCount++
-	}
+	query.WriteString(" WHERE ")
+	query.WriteString(strings.Join(whereClauses, " AND "))
 
-	if len(whereClauses) > 0 {
-		query.WriteString(" WHERE ")
-		query.WriteString(strings.Join(whereClauses, " AND "))
+	query.WriteString(" ORDER BY ")
+	if filter.Query != "" && len(filter.Sort) == 0 {
+		query.WriteString("rank DESC, ")
 	}
+	query.WriteString(orderBy(filter.Ordering()))
 
 	if filter.Limit > 0 {
 		query.WriteString(" LIMIT $")
@@ -86,9 +75,6 @@ func (p PostgreSQL) ReadFilteredSongs(filter *models.Filter) ([]models.Song, err
 	query.WriteString(";")
 
 	rows, err := p.pool.Query(ctx, query.String(), args...)
-
-	fmt.Println(query.String(), args)
-
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -98,12 +84,203 @@ func (p PostgreSQL) ReadFilteredSongs(filter *models.Filter) ([]models.Song, err
 
 	for rows.Next() {
 		var song models.Song
-		err = rows.Scan(&song.ID, &song.Title, &song.Group, &song.ReleaseDate, &song.Text, &song.Link)
+		dest := []any{&song.ID, &song.Title, &song.Group, &song.ReleaseDate, &song.Text, &song.Link, &song.Lang, &song.Version, &song.Status, &song.Sources, &song.EnrichedAt, &song.DeletedAt}
+		if filter.Query != "" {
+			dest = append(dest, &song.Rank, &song.Snippet)
+		}
+		err = rows.Scan(dest...)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
+		song.Snippet = headlineMarkup.Replace(html.EscapeString(song.Snippet))
 		songs = append(songs, song)
 	}
 
 	return songs, nil
 }
+
+func (p PostgreSQL) CountSongs(ctx context.Context, filter *models.Filter) (int, error) {
+	const op = "postgresql.CountSongs"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	var total int
+
+	args := make([]any, 0)
+	whereClauses := p.whereClauses(filter, &args)
+
+	var query strings.Builder
+	query.WriteString("SELECT COUNT(*) FROM songs s JOIN groups g ON s.group_id=g.id")
+	query.WriteString(" WHERE ")
+	query.WriteString(strings.Join(whereClauses, " AND "))
+	query.WriteString(";")
+
+	err := p.pool.QueryRow(ctx, query.String(), args...).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return total, nil
+}
+
+// whereClauses compiles the filter conditions into SQL, appending their
+// arguments to args. Pagination fields of the filter are ignored.
+func (p PostgreSQL) whereClauses(filter *models.Filter, args *[]any) []string {
+	whereClauses := make([]string, 0, 6)
+	varCount := len(*args) + 1
+
+	// Songs in the trash are only visible when they are asked for explicitly.
+	if filter.Deleted {
+		whereClauses = append(whereClauses, "s.deleted_at IS NOT NULL")
+	} else {
+		whereClauses = append(whereClauses, "s.deleted_at IS NULL")
+	}
+
+	// Full-text search matches the query against both the Russian and the
+	// English configuration, so lyrics in either language are found.
+	if filter.Query != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("s.search_vector @@ %s", tsQuery(varCount)))
+		*args = append(*args, &filter.Query)
+		varCount++
+	}
+
+	if len(filter.Titles) > 0 {
+		whereClauses = append(whereClauses, matchClause("s.title", filter.TitleMatch, varCount))
+		*args = append(*args, matchArg(filter.Titles, filter.TitleMatch))
+		varCount++
+	}
+
+	if len(filter.Groups) > 0 {
+		whereClauses = append(whereClauses, matchClause("g.name", filter.GroupMatch, varCount))
+		*args = append(*args, matchArg(filter.Groups, filter.GroupMatch))
+		varCount++
+	}
+
+	if filter.GroupID != 0 {
+		whereClauses = append(whereClauses, fmt.Sprintf("group_id = $%d", varCount))
+		*args = append(*args, &filter.GroupID)
+		varCount++
+	}
+
+	if !filter.ReleaseDate.Equal(time.Time{}) {
+		whereClauses = append(whereClauses, fmt.Sprintf("release_date = $%d", varCount))
+		*args = append(*args, &filter.ReleaseDate)
+		varCount++
+	}
+
+	if !filter.ReleaseDateFrom.IsZero() {
+		whereClauses = append(whereClauses, fmt.Sprintf("release_date >= $%d", varCount))
+		*args = append(*args, &filter.ReleaseDateFrom)
+		varCount++
+	}
+
+	if !filter.ReleaseDateTo.IsZero() {
+		whereClauses = append(whereClauses, fmt.Sprintf("release_date <= $%d", varCount))
+		*args = append(*args, &filter.ReleaseDateTo)
+		varCount++
+	}
+
+	// The year is compiled into a date range, so an index on release_date can be used.
+	if filter.Year != 0 {
+		whereClauses = append(whereClauses, fmt.Sprintf("release_date >= $%d AND release_date < $%d", varCount, varCount+1))
+		*args = append(*args, time.Date(filter.Year, time.January, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(filter.Year+1, time.January, 1, 0, 0, 0, 0, time.UTC))
+		varCount += 2
+	}
+
+	if filter.Text != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("song_text ILIKE '%%' || $%d || '%%'", varCount))
+		*args = append(*args, escapeLike(filter.Text))
+		varCount++
+	}
+
+	if filter.Link != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("link = $%d", varCount))
+		*args = append(*args, &filter.Link)
+	}
+
+	return whereClauses
+}
+
+// sortColumns maps the sort fields to their columns and the types their
+// cursor values are cast to. Cursor values are always bound as text.
+var sortColumns = map[string]struct{ column, cast string }{
+	models.SortID:          {"s.id", "int"},
+	models.SortTitle:       {"s.title", "text"},
+	models.SortGroup:       {"g.name", "text"},
+	models.SortReleaseDate: {"s.release_date", "date"},
+}
+
+func orderBy(ordering []models.SortField) string {
+	keys := make([]string, 0, len(ordering))
+	for _, field := range ordering {
+		key := sortColumns[field.Field].column
+		if field.Desc {
+			key += " DESC"
+		}
+		keys = append(keys, key)
+	}
+	return strings.Join(keys, ", ")
+}
+
+// keysetClause selects the songs that come after the cursor in the given
+// ordering: (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ..., with < for
+// descending keys.
+func keysetClause(ordering []models.SortField, cursor *models.Cursor, args *[]any) string {
+	placeholders := make([]string, len(ordering))
+	for i, field := range ordering {
+		*args = append(*args, cursor.Values[i])
+		placeholders[i] = fmt.Sprintf("$%d::text", len(*args))
+		if cast := sortColumns[field.Field].cast; cast != "text" {
+			placeholders[i] += "::" + cast
+		}
+	}
+
+	alternatives := make([]string, 0, len(ordering))
+	for i, field := range ordering {
+		conditions := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			conditions = append(conditions, fmt.Sprintf("%s = %s", sortColumns[ordering[j].Field].column, placeholders[j]))
+		}
+		op := ">"
+		if field.Desc {
+			op = "<"
+		}
+		conditions = append(conditions, fmt.Sprintf("%s %s %s", sortColumns[field.Field].column, op, placeholders[i]))
+		alternatives = append(alternatives, "("+strings.Join(conditions, " AND ")+")")
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")"
+}
+
+// matchClause compares column with any of the values bound to parameter n.
+func matchClause(column, match string, n int) string {
+	if match == models.MatchPrefix || match == models.MatchContains {
+		return fmt.Sprintf("%s ILIKE ANY($%d)", column, n)
+	}
+	return fmt.Sprintf("%s = ANY($%d)", column, n)
+}
+
+// matchArg turns filter values into the argument of matchClause, escaping
+// them into LIKE patterns for partial matching.
+func matchArg(values []string, match string) []string {
+	if match != models.MatchPrefix && match != models.MatchContains {
+		return values
+	}
+	patterns := make([]string, 0, len(values))
+	for _, value := range values {
+		pattern := escapeLike(value) + "%"
+		if match == models.MatchContains {
+			pattern = "%" + pattern
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns
+}
+
+// tsQuery returns the tsquery for the search text bound to parameter n.
+func tsQuery(n int) string {
+	return fmt.Sprintf("(websearch_to_tsquery('russian', $%[1]d) || websearch_to_tsquery('english', $%[1]d))", n)
+}
+
+// escapeLike escapes the LIKE wildcards in value, so it is matched literally.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
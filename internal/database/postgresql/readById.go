@@ -3,24 +3,23 @@ package postgresql
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/jackc/pgx/v4"
 	"github.com/notblinkyet/song-library-api/internal/models"
 )
 
-func (p PostgreSQL) ReadByID(id int) (*models.Song, error) {
+func (p PostgreSQL) ReadByID(ctx context.Context, id int) (*models.Song, error) {
 	const op = "postgresql.ReadByID"
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
 	var song models.Song
 
-	query := `SELECT s.id, s.title, g.name, s.release_date, s.song_text, s.link 
+	query := `SELECT s.id, s.title, g.name, s.release_date, s.song_text, s.link, s.lang, s.version, s.status, s.sources, s.enriched_at
 		FROM songs s JOIN groups g ON g.id = s.group_id
-		WHERE s.id=$1
+		WHERE s.id=$1 AND s.deleted_at IS NULL
 	`
 	err := p.pool.QueryRow(ctx, query, &id).Scan(&song.ID, &song.Title, &song.Group,
-		&song.ReleaseDate, &song.Text, &song.Link)
+		&song.ReleaseDate, &song.Text, &song.Link, &song.Lang, &song.Version, &song.Status, &song.Sources, &song.EnrichedAt)
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -0,0 +1,30 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+func (p PostgreSQL) ReadGroupByID(ctx context.Context, id int) (*models.Group, error) {
+	const op = "postgresql.ReadGroupByID"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	var group models.Group
+
+	query := `SELECT g.id, g.name, COUNT(s.id)
+		FROM groups g LEFT JOIN songs s ON s.group_id = g.id AND s.deleted_at IS NULL
+		WHERE g.id = $1
+		GROUP BY g.id, g.name;
+	`
+	err := p.pool.QueryRow(ctx, query, &id).Scan(&group.ID, &group.Name, &group.SongCount)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &group, nil
+}
@@ -0,0 +1,42 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+func (p PostgreSQL) ReadGroups(ctx context.Context) ([]models.Group, error) {
+	const op = "postgresql.ReadGroups"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	query := `SELECT g.id, g.name, COUNT(s.id)
+		FROM groups g LEFT JOIN songs s ON s.group_id = g.id AND s.deleted_at IS NULL
+		GROUP BY g.id, g.name
+		ORDER BY g.id;
+	`
+
+	rows, err := p.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	groups := make([]models.Group, 0)
+
+	for rows.Next() {
+		var group models.Group
+		err = rows.Scan(&group.ID, &group.Name, &group.SongCount)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		groups = append(groups, group)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return groups, nil
+}
@@ -0,0 +1,102 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// RefreshSong stores details of the song fetched again from the song info
+// API and increments its version. Like UpdateSong, it only succeeds if the
+// stored song still has song.Version. On success song.Version and
+// song.EnrichedAt hold the new values.
+func (p PostgreSQL) RefreshSong(ctx context.Context, song *models.Song) error {
+	const op = "postgresql.RefreshSong"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	err := p.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		query := `UPDATE songs SET release_date=$1, song_text=$2, link=$3, sources=$4,
+				status='ready', enriched_at=now(), version=version+1
+			WHERE id=$5 AND deleted_at IS NULL AND version=$6
+			RETURNING version, enriched_at;`
+
+		err := tx.QueryRow(ctx, query, &song.ReleaseDate, &song.Text, &song.Link, sources(song.Sources),
+			&song.ID, &song.Version).Scan(&song.Version, &song.EnrichedAt)
+		if err == pgx.ErrNoRows {
+			return versionConflict(ctx, tx, song.ID)
+		}
+		if err != nil {
+			return err
+		}
+
+		return recordVersion(ctx, tx, song.ID, models.OperationRefresh)
+	})
+
+	if err != nil {
+		if err == ErrNotFound || err == ErrVersionMismatch {
+			return err
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// MarkSongRefreshed records that the details of the song have been checked
+// and are up to date, without creating a new version.
+func (p PostgreSQL) MarkSongRefreshed(ctx context.Context, id int) error {
+	const op = "postgresql.MarkSongRefreshed"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	query := "UPDATE songs SET enriched_at=now() WHERE id=$1 AND deleted_at IS NULL;"
+	commandTag, err := p.pool.Exec(ctx, query, &id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ReadStaleSongs returns up to limit songs whose details were last fetched
+// before the given time or never, or that have empty details last fetched
+// before emptyBefore, least recently fetched first. Songs that are still
+// waiting for their enrichment job are skipped.
+func (p PostgreSQL) ReadStaleSongs(ctx context.Context, before, emptyBefore time.Time, limit int) ([]models.Song, error) {
+	const op = "postgresql.ReadStaleSongs"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	query := `SELECT s.id, s.title, g.name, s.release_date, s.song_text, s.link, s.version, s.status, s.sources, s.enriched_at
+		FROM songs s JOIN groups g ON g.id = s.group_id
+		WHERE s.deleted_at IS NULL AND s.status <> 'pending' AND (s.enriched_at IS NULL OR s.enriched_at < $1
+			OR ((s.release_date = '0001-01-01' OR s.song_text = '' OR s.link = '') AND s.enriched_at < $2))
+		ORDER BY s.enriched_at NULLS FIRST, s.id
+		LIMIT $3;`
+
+	rows, err := p.pool.Query(ctx, query, &before, &emptyBefore, &limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var songs []models.Song
+	for rows.Next() {
+		var song models.Song
+		err = rows.Scan(&song.ID, &song.Title, &song.Group, &song.ReleaseDate, &song.Text, &song.Link,
+			&song.Version, &song.Status, &song.Sources, &song.EnrichedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		songs = append(songs, song)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return songs, nil
+}
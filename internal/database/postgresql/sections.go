@@ -0,0 +1,46 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// ReadSongSections returns the stored structure of the lyrics of the song, or
+// nil if it hasn't been parsed yet.
+func (p PostgreSQL) ReadSongSections(ctx context.Context, songID int) (*models.SongSections, error) {
+	const op = "postgresql.ReadSongSections"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	sections := models.SongSections{SongID: songID}
+
+	query := "SELECT version, sections FROM song_sections WHERE song_id=$1;"
+	err := p.pool.QueryRow(ctx, query, &songID).Scan(&sections.Version, &sections.Sections)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &sections, nil
+}
+
+// SaveSongSections stores the structure of the lyrics of the song, unless a
+// structure parsed from a later version of the song is stored already.
+func (p PostgreSQL) SaveSongSections(ctx context.Context, sections *models.SongSections) error {
+	const op = "postgresql.SaveSongSections"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	query := `INSERT INTO song_sections (song_id, version, sections)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (song_id) DO UPDATE SET version=EXCLUDED.version, sections=EXCLUDED.sections, parsed_at=now()
+			WHERE song_sections.version <= EXCLUDED.version;`
+	_, err := p.pool.Exec(ctx, query, &sections.SongID, &sections.Version, &sections.Sections)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
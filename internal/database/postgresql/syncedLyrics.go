@@ -0,0 +1,66 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+func (p PostgreSQL) ReadSyncedLyrics(ctx context.Context, songID int) (*models.SyncedLyrics, error) {
+	const op = "postgresql.ReadSyncedLyrics"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	lyrics := models.SyncedLyrics{SongID: songID}
+
+	query := `SELECT l.lines, l.updated_at
+		FROM synced_lyrics l JOIN songs s ON s.id = l.song_id
+		WHERE l.song_id=$1 AND s.deleted_at IS NULL;`
+	err := p.pool.QueryRow(ctx, query, &songID).Scan(&lyrics.Lines, &lyrics.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &lyrics, nil
+}
+
+// SaveSyncedLyrics attaches the lyrics to the song, replacing the previous
+// ones. On success lyrics.UpdatedAt holds the time of the change.
+func (p PostgreSQL) SaveSyncedLyrics(ctx context.Context, lyrics *models.SyncedLyrics) error {
+	const op = "postgresql.SaveSyncedLyrics"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	query := `INSERT INTO synced_lyrics (song_id, lines)
+		SELECT id, $2 FROM songs WHERE id=$1 AND deleted_at IS NULL
+		ON CONFLICT (song_id) DO UPDATE SET lines=EXCLUDED.lines, updated_at=now()
+		RETURNING updated_at;`
+	err := p.pool.QueryRow(ctx, query, &lyrics.SongID, &lyrics.Lines).Scan(&lyrics.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return ErrNotFound
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (p PostgreSQL) DeleteSyncedLyrics(ctx context.Context, songID int) error {
+	const op = "postgresql.DeleteSyncedLyrics"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	query := `DELETE FROM synced_lyrics l USING songs s
+		WHERE l.song_id=$1 AND s.id = l.song_id AND s.deleted_at IS NULL;`
+	commandTag, err := p.pool.Exec(ctx, query, &songID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
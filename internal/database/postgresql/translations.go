@@ -0,0 +1,119 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// ReadTranslations returns the translations of the song ordered by language,
+// or an empty slice if it has none.
+func (p PostgreSQL) ReadTranslations(ctx context.Context, songID int) ([]models.Translation, error) {
+	const op = "postgresql.ReadTranslations"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	query := `SELECT t.lang, t.text, t.version, t.song_version, t.song_text_hash <> md5(s.song_text), t.updated_at
+		FROM song_translations t JOIN songs s ON s.id = t.song_id
+		WHERE t.song_id=$1 AND s.deleted_at IS NULL
+		ORDER BY t.lang;`
+	rows, err := p.pool.Query(ctx, query, &songID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	translations := make([]models.Translation, 0)
+	for rows.Next() {
+		translation := models.Translation{SongID: songID}
+		err = rows.Scan(&translation.Lang, &translation.Text, &translation.Version, &translation.SongVersion,
+			&translation.Outdated, &translation.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		translations = append(translations, translation)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// No translations may also mean there is no such song.
+	if len(translations) == 0 {
+		var exists bool
+		query = "SELECT EXISTS(SELECT 1 FROM songs WHERE id = $1 AND deleted_at IS NULL);"
+		if err = p.pool.QueryRow(ctx, query, &songID).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if !exists {
+			return nil, ErrNotFound
+		}
+	}
+	return translations, nil
+}
+
+func (p PostgreSQL) ReadTranslation(ctx context.Context, songID int, lang string) (*models.Translation, error) {
+	const op = "postgresql.ReadTranslation"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	translation := models.Translation{SongID: songID, Lang: lang}
+
+	query := `SELECT t.text, t.version, t.song_version, t.song_text_hash <> md5(s.song_text), t.updated_at
+		FROM song_translations t JOIN songs s ON s.id = t.song_id
+		WHERE t.song_id=$1 AND t.lang=$2 AND s.deleted_at IS NULL;`
+	err := p.pool.QueryRow(ctx, query, &songID, &lang).Scan(&translation.Text, &translation.Version,
+		&translation.SongVersion, &translation.Outdated, &translation.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &translation, nil
+}
+
+// SaveTranslation creates the translation of the song or replaces its text,
+// incrementing its version. On success translation.Version and
+// translation.UpdatedAt hold the new values.
+func (p PostgreSQL) SaveTranslation(ctx context.Context, translation *models.Translation) (bool, error) {
+	const op = "postgresql.SaveTranslation"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	// The hash of the text of the song tells whether the text has changed
+	// since, which its version can't: restoring the song or refreshing its
+	// details also increments the version. xmax is zero only for inserted rows.
+	query := `INSERT INTO song_translations (song_id, lang, text, song_version, song_text_hash)
+		SELECT id, $2, $3, $4, md5(song_text) FROM songs WHERE id=$1 AND deleted_at IS NULL
+		ON CONFLICT (song_id, lang) DO UPDATE SET text=EXCLUDED.text, song_version=EXCLUDED.song_version,
+			song_text_hash=EXCLUDED.song_text_hash, version=song_translations.version+1, updated_at=now()
+		RETURNING version, updated_at, xmax = 0;`
+	var created bool
+	err := p.pool.QueryRow(ctx, query, &translation.SongID, &translation.Lang, &translation.Text,
+		&translation.SongVersion).Scan(&translation.Version, &translation.UpdatedAt, &created)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return false, ErrNotFound
+		}
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	return created, nil
+}
+
+func (p PostgreSQL) DeleteTranslation(ctx context.Context, songID int, lang string) error {
+	const op = "postgresql.DeleteTranslation"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	query := `DELETE FROM song_translations t USING songs s
+		WHERE t.song_id=$1 AND t.lang=$2 AND s.id = t.song_id AND s.deleted_at IS NULL;`
+	commandTag, err := p.pool.Exec(ctx, query, &songID, &lang)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -3,47 +3,63 @@ package postgresql
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/jackc/pgx/v4"
 	"github.com/notblinkyet/song-library-api/internal/models"
 )
 
-func (p PostgreSQL) UpdateSong(song *models.Song) error {
+// UpdateSong overwrites the song and increments its version. When
+// song.Version is set, the update only succeeds if the stored song still has
+// that version, otherwise ErrVersionMismatch is returned. On success
+// song.Version holds the new version.
+func (p PostgreSQL) UpdateSong(ctx context.Context, song *models.Song) error {
 	const op = "postgresql.UpdateSong"
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
-	var group_id int
 
-	query := `SELECT id FROM groups WHERE name=$1`
+	err := p.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		group_id, err := groupID(ctx, tx, song.Group)
+		if err != nil {
+			return err
+		}
 
-	err := p.pool.QueryRow(ctx, query, &song.Group).Scan(&group_id)
+		query := `UPDATE songs SET title=$1, group_id=$2, release_date=$3, song_text=$4, link=$5, sources=$8, lang=$9,
+				version=version+1
+			WHERE id=$6 AND deleted_at IS NULL AND ($7 = 0 OR version = $7)
+			RETURNING version;`
 
-	if err == pgx.ErrNoRows {
-		query = `
-			INSERT INTO groups (name)
-			VALUES($1) RETURNING id;
-		`
-		err = p.pool.QueryRow(ctx, query, &song.Group).Scan(&group_id)
+		err = tx.QueryRow(ctx, query, &song.Title,
+			&group_id, &song.ReleaseDate, &song.Text, &song.Link, &song.ID, &song.Version, sources(song.Sources), &song.Lang).Scan(&song.Version)
+		if err == pgx.ErrNoRows {
+			return versionConflict(ctx, tx, song.ID)
+		}
 		if err != nil {
-			return fmt.Errorf("group not found: %s", song.Group)
+			return err
 		}
-	} else if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
-	}
 
-	query = "UPDATE songs SET title=$1, group_id=$2, release_date=$3, song_text=$4, link=$5 WHERE id=$6;"
+		return recordVersion(ctx, tx, song.ID, models.OperationUpdate)
+	})
 
-	commandTag, err := p.pool.Exec(ctx, query, &song.Title,
-		&group_id, &song.ReleaseDate, &song.Text, &song.Link, &song.ID)
 	if err != nil {
-		if err == ErrNoAffectedRows {
-			return ErrNotFound
+		if err == ErrNotFound || err == ErrVersionMismatch {
+			return err
 		}
 		return fmt.Errorf("%s: %w", op, err)
 	}
-	if commandTag.RowsAffected() == 0 {
-		return fmt.Errorf("%s: %w", op, ErrNoAffectedRows)
-	}
 	return nil
 }
+
+// versionConflict explains why a conditional write of the song affected no
+// rows: either the song doesn't exist (or is in the trash), or it has a
+// different version.
+func versionConflict(ctx context.Context, q querier, id int) error {
+	var exists bool
+	query := "SELECT EXISTS(SELECT 1 FROM songs WHERE id = $1 AND deleted_at IS NULL);"
+	if err := q.QueryRow(ctx, query, &id).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return ErrVersionMismatch
+	}
+	return ErrNotFound
+}
@@ -0,0 +1,28 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+func (p PostgreSQL) UpdateGroup(ctx context.Context, group *models.Group) error {
+	const op = "postgresql.UpdateGroup"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	query := "UPDATE groups SET name=$1 WHERE id=$2;"
+
+	commandTag, err := p.pool.Exec(ctx, query, &group.Name, &group.ID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// recordVersion appends the current state of the song to its history.
+// It must run in the same transaction as the operation it records, after
+// the song row has been written (or, for deletes, before it is removed).
+func recordVersion(ctx context.Context, q querier, songID int, operation string) error {
+	const op = "postgresql.recordVersion"
+
+	query := `
+		INSERT INTO song_versions (song_id, version, operation, title, group_name, release_date, song_text, link)
+		SELECT s.id,
+			COALESCE((SELECT MAX(v.version) FROM song_versions v WHERE v.song_id = s.id), 0) + 1,
+			$2, s.title, g.name, s.release_date, s.song_text, s.link
+		FROM songs s JOIN groups g ON g.id = s.group_id
+		WHERE s.id = $1;
+	`
+
+	commandTag, err := q.Exec(ctx, query, &songID, &operation)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+const versionColumns = `v.version, v.operation, v.created_at,
+	v.song_id, v.title, v.group_name, v.release_date, v.song_text, v.link`
+
+func scanVersion(row pgx.Row, version *models.SongVersion) error {
+	return row.Scan(&version.Version, &version.Operation, &version.CreatedAt,
+		&version.Song.ID, &version.Song.Title, &version.Song.Group,
+		&version.Song.ReleaseDate, &version.Song.Text, &version.Song.Link)
+}
+
+func (p PostgreSQL) ReadSongVersions(ctx context.Context, songID int) ([]models.SongVersion, error) {
+	const op = "postgresql.ReadSongVersions"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	query := "SELECT " + versionColumns + " FROM song_versions v WHERE v.song_id = $1 ORDER BY v.version;"
+
+	rows, err := p.pool.Query(ctx, query, &songID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	versions := make([]models.SongVersion, 0)
+
+	for rows.Next() {
+		var version models.SongVersion
+		if err = scanVersion(rows, &version); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		versions = append(versions, version)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(versions) == 0 {
+		return nil, ErrNotFound
+	}
+	return versions, nil
+}
+
+func (p PostgreSQL) ReadSongVersion(ctx context.Context, songID, version int) (*models.SongVersion, error) {
+	const op = "postgresql.ReadSongVersion"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	res, err := readVersion(ctx, p.pool, songID, version)
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return res, nil
+}
+
+func readVersion(ctx context.Context, q querier, songID, version int) (*models.SongVersion, error) {
+	var res models.SongVersion
+
+	query := "SELECT " + versionColumns + " FROM song_versions v WHERE v.song_id = $1 AND v.version = $2;"
+
+	err := scanVersion(q.QueryRow(ctx, query, &songID, &version), &res)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &res, nil
+}
+
+// RestoreSongVersion brings the song back to the state stored in the given
+// version. A song in the trash is taken out of it, and a song that has been
+// purged since is recreated with its old id.
+func (p PostgreSQL) RestoreSongVersion(ctx context.Context, songID, version int) (*models.Song, error) {
+	const op = "postgresql.RestoreSongVersion"
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	var song *models.Song
+
+	err := p.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		v, err := readVersion(ctx, tx, songID, version)
+		if err != nil {
+			return err
+		}
+		song = &v.Song
+
+		group_id, err := groupID(ctx, tx, song.Group)
+		if err != nil {
+			return err
+		}
+
+		query := `UPDATE songs SET title=$1, group_id=$2, release_date=$3, song_text=$4, link=$5,
+				sources='{}', deleted_at=NULL, version=version+1
+			WHERE id=$6
+			RETURNING version;`
+
+		err = tx.QueryRow(ctx, query, &song.Title,
+			&group_id, &song.ReleaseDate, &song.Text, &song.Link, &song.ID).Scan(&song.Version)
+		if err == pgx.ErrNoRows {
+			// The version goes on from the history, which outlives the song.
+			query = `INSERT INTO songs (id, title, group_id, release_date, song_text, link, version)
+				VALUES ($1, $2, $3, $4, $5, $6, (SELECT MAX(version) + 1 FROM song_versions WHERE song_id = $1))
+				RETURNING version;`
+			err = tx.QueryRow(ctx, query, &song.ID, &song.Title,
+				&group_id, &song.ReleaseDate, &song.Text, &song.Link).Scan(&song.Version)
+		}
+		if err != nil {
+			return err
+		}
+
+		return recordVersion(ctx, tx, song.ID, models.OperationRestore)
+	})
+
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return song, nil
+}
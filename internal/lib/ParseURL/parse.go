@@ -1,9 +1,22 @@
 package parseurl
 
 import (
+	"errors"
+	"math"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+var (
+	ErrNotInt   = errors.New("must be an integer")
+	ErrNotDate  = errors.New("must be a date in DD.MM.YYYY format")
+	ErrNotBool  = errors.New("must be true or false")
+	ErrNotFloat = errors.New("must be a number")
+	ErrNotRange = errors.New("must be a list of numbers and ranges like 1-3,5,8-")
 )
 
 func ParseString(queryValuer url.Values, key, defaultValue string) string {
@@ -14,27 +27,108 @@ func ParseString(queryValuer url.Values, key, defaultValue string) string {
 	return value
 }
 
-func ParseInt(queryValuer url.Values, key string, defaultValue int) int {
+// ParseStrings returns all non-empty values of a repeated query parameter,
+// e.g. group=Muse&group=Queen.
+func ParseStrings(queryValuer url.Values, key string) []string {
+	var res []string
+	for _, value := range queryValuer[key] {
+		if value != "" {
+			res = append(res, value)
+		}
+	}
+	return res
+}
+
+// ParseInt returns the integer value of the parameter, or defaultValue if
+// it is missing. A malformed value is reported with ErrNotInt.
+func ParseInt(queryValuer url.Values, key string, defaultValue int) (int, error) {
 	valueString := queryValuer.Get(key)
 
 	if valueString == "" {
-		return defaultValue
+		return defaultValue, nil
 	}
 
-	if value, err := strconv.Atoi(valueString); err == nil {
-		return value
+	value, err := strconv.Atoi(valueString)
+	if err != nil {
+		return defaultValue, ErrNotInt
 	}
-	return defaultValue
+	return value, nil
 }
 
-func ParseTime(queryValuer url.Values, key string, defaultValue time.Time) time.Time {
+// ParseTime returns the DD.MM.YYYY date value of the parameter, or
+// defaultValue if it is missing. A malformed value is reported with ErrNotDate.
+func ParseTime(queryValuer url.Values, key string, defaultValue time.Time) (time.Time, error) {
 	value := queryValuer.Get(key)
 	if value == "" {
-		return defaultValue
+		return defaultValue, nil
 	}
 	res, err := time.Parse("02.01.2006", value)
 	if err != nil {
-		return defaultValue
+		return defaultValue, ErrNotDate
 	}
-	return res
+	return res, nil
+}
+
+// ParseBool returns the boolean value of the parameter, or defaultValue if
+// it is missing. A malformed value is reported with ErrNotBool.
+func ParseBool(queryValuer url.Values, key string, defaultValue bool) (bool, error) {
+	valueString := queryValuer.Get(key)
+
+	if valueString == "" {
+		return defaultValue, nil
+	}
+
+	value, err := strconv.ParseBool(valueString)
+	if err != nil {
+		return defaultValue, ErrNotBool
+	}
+	return value, nil
+}
+
+// ParseFloat returns the finite number value of the parameter, or
+// defaultValue if it is missing. A malformed value is reported with
+// ErrNotFloat.
+func ParseFloat(queryValuer url.Values, key string, defaultValue float64) (float64, error) {
+	valueString := queryValuer.Get(key)
+
+	if valueString == "" {
+		return defaultValue, nil
+	}
+
+	value, err := strconv.ParseFloat(valueString, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return defaultValue, ErrNotFloat
+	}
+	return value, nil
+}
+
+// ParseRanges returns the ranges of a parameter like "1-3,5,8-". A range
+// without an end, like "8-", lasts to the end and has To set to 0. Numbers
+// start at 1. A malformed value is reported with ErrNotRange.
+func ParseRanges(queryValuer url.Values, key string) ([]models.Range, error) {
+	value := queryValuer.Get(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	var res []models.Range
+	for _, part := range strings.Split(value, ",") {
+		fromString, toString, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		from, err := strconv.Atoi(fromString)
+		if err != nil || from < 1 {
+			return nil, ErrNotRange
+		}
+		to := from
+		if isRange {
+			to = 0
+			if toString != "" {
+				to, err = strconv.Atoi(toString)
+				if err != nil || to < from {
+					return nil, ErrNotRange
+				}
+			}
+		}
+		res = append(res, models.Range{From: from, To: to})
+	}
+	return res, nil
 }
@@ -0,0 +1,55 @@
+package parseurl
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+func TestParseRanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []models.Range
+		wantErr bool
+	}{
+		{name: "missing", value: "", want: nil},
+		{name: "single number", value: "5", want: []models.Range{{From: 5, To: 5}}},
+		{name: "closed range", value: "1-3", want: []models.Range{{From: 1, To: 3}}},
+		{name: "open range", value: "8-", want: []models.Range{{From: 8, To: 0}}},
+		{name: "one-element range", value: "2-2", want: []models.Range{{From: 2, To: 2}}},
+		{
+			name:  "list with spaces",
+			value: "1-3, 5 ,8-",
+			want:  []models.Range{{From: 1, To: 3}, {From: 5, To: 5}, {From: 8, To: 0}},
+		},
+		{name: "zero", value: "0", wantErr: true},
+		{name: "negative", value: "-1", wantErr: true},
+		{name: "reversed range", value: "3-1", wantErr: true},
+		{name: "range without start", value: "-3", wantErr: true},
+		{name: "empty item", value: "1,,2", wantErr: true},
+		{name: "not a number", value: "one", wantErr: true},
+		{name: "bad end", value: "1-x", wantErr: true},
+		{name: "two dashes", value: "1-2-3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRanges(url.Values{"verses": {tt.value}}, "verses")
+			if tt.wantErr {
+				if !errors.Is(err, ErrNotRange) {
+					t.Fatalf("error = %v, want %v", err, ErrNotRange)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
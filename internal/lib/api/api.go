@@ -1,10 +1,14 @@
 package api
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"math/rand/v2"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/notblinkyet/song-library-api/internal/models"
 )
@@ -12,38 +16,229 @@ import (
 var (
 	ErrBadRequest     = errors.New("bad request")
 	ErrInternalServer = errors.New("internal server error")
+	ErrUnreachable    = errors.New("api is unreachable")
+	ErrCircuitOpen    = errors.New("api is unavailable, circuit breaker is open")
 )
 
+// maxResponseSize limits how much of a response is read, lyrics included.
+const maxResponseSize = 4 << 20
+
+// Options configure timeouts, retries and the circuit breaker of the client.
+type Options struct {
+	Timeout          time.Duration // Deadline of a single attempt.
+	Deadline         time.Duration // Deadline of a whole call, retries and delays included; 0 means none.
+	MaxRetries       int           // Retries after the first attempt.
+	BackoffBase      time.Duration // Delay before the first retry, doubled for every next one.
+	BackoffMax       time.Duration // Upper bound of the delay, including Retry-After.
+	BreakerThreshold int           // Failed calls in a row that open the breaker; 0 disables it.
+	BreakerCooldown  time.Duration // How long the breaker stays open.
+
+	Request RequestTemplate // How requests are built.
+	Mapping ResponseMapping // Where the song details are in responses.
+}
+
 type ApiClient struct {
-	ApiURL string
+	ApiURL  string
+	Timeout time.Duration
+
+	client      *http.Client
+	deadline    time.Duration
+	maxRetries  int
+	backoffBase time.Duration
+	backoffMax  time.Duration
+	breaker     *Breaker
+	request     RequestTemplate
+	mapping     ResponseMapping
 }
 
-func NewApiClient(url string) *ApiClient {
+func NewApiClient(url string, client *http.Client, opts Options) *ApiClient {
+	if client == nil {
+		client = http.DefaultClient
+	}
 	return &ApiClient{
-		ApiURL: url,
+		ApiURL:      url,
+		Timeout:     opts.Timeout,
+		client:      client,
+		deadline:    opts.Deadline,
+		maxRetries:  opts.MaxRetries,
+		backoffBase: opts.BackoffBase,
+		backoffMax:  opts.BackoffMax,
+		breaker:     NewBreaker(opts.BreakerThreshold, opts.BreakerCooldown),
+		request:     opts.Request.withDefaults(),
+		mapping:     opts.Mapping.withDefaults(),
 	}
 }
 
-func (a *ApiClient) GetMoreAboutSong(req *models.CreateSongRequest) (*models.Song, error) {
+// GetMoreAboutSong asks the API for the details of the song. Failed attempts
+// are retried with exponential backoff until the deadline of the call, and
+// after repeated failures the circuit breaker rejects calls with
+// ErrCircuitOpen without contacting the API.
+func (a *ApiClient) GetMoreAboutSong(ctx context.Context, req *models.CreateSongRequest) (*models.Song, error) {
 	const op = "api.GetMoreAboutSong"
-	resp, err := http.Get(fmt.Sprintf("%s/info?song=%s&group=%s", a.ApiURL, req.Title, req.Group))
-	if err != nil {
-		return nil, err
+
+	if err := ctx.Err(); err != nil {
+		// Nothing is left of the time to ask the API.
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	if resp.StatusCode != http.StatusOK {
-		if resp.StatusCode == 400 {
-			return nil, fmt.Errorf("%s: %w", op, ErrBadRequest)
-		}
-		return nil, fmt.Errorf("%s: %w", op, ErrInternalServer)
+	if !a.breaker.Allow() {
+		return nil, fmt.Errorf("%s: %w", op, ErrCircuitOpen)
 	}
 
-	var res models.Song
+	if a.deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.deadline)
+		defer cancel()
+	}
 
-	err = json.NewDecoder(resp.Body).Decode(&res)
+	res, err := a.getWithRetries(ctx, req)
+	switch {
+	case err == nil, errors.Is(err, ErrBadRequest):
+		// The API answered, even if it didn't know the song.
+		a.breaker.Success()
+	case errors.Is(ctx.Err(), context.Canceled):
+		// The caller gave up, which says nothing about the API. Running out
+		// of time does count as a failure.
+		a.breaker.Ignore()
+	default:
+		a.breaker.Failure(err)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
+
 	res.Group = req.Group
 	res.Title = req.Title
-	return &res, nil
+	return res, nil
+}
+
+// Status reports the state of the circuit breaker of the client.
+func (a *ApiClient) Status() []models.UpstreamStatus {
+	status := a.breaker.Status()
+	status.Name = a.ApiURL
+	return []models.UpstreamStatus{status}
+}
+
+// getWithRetries makes attempts until one succeeds, fails permanently, the
+// retries are used up or the context is done. A retry that couldn't start
+// before the deadline of the context isn't waited for.
+func (a *ApiClient) getWithRetries(ctx context.Context, req *models.CreateSongRequest) (*models.Song, error) {
+	for attempt := 0; ; attempt++ {
+		res, retryAfter, err := a.get(ctx, req)
+		if err == nil || !retryable(err) || attempt >= a.maxRetries {
+			return res, err
+		}
+
+		delay := a.backoff(attempt)
+		if retryAfter > 0 {
+			if retryAfter > a.backoffMax {
+				// The API asked to wait longer than we are willing to.
+				return nil, err
+			}
+			delay = max(delay, retryAfter)
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return nil, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// get makes a single attempt. For responses that may be retried later it
+// also returns the delay requested by the Retry-After header.
+func (a *ApiClient) get(ctx context.Context, req *models.CreateSongRequest) (*models.Song, time.Duration, error) {
+	if a.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.Timeout)
+		defer cancel()
+	}
+
+	httpReq, err := a.newRequest(ctx, req)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := a.client.Do(httpReq)
+	if err != nil {
+		return nil, 0, &attemptError{err: fmt.Errorf("%w: %w", ErrUnreachable, err), retry: true}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode == http.StatusBadRequest {
+			return nil, 0, ErrBadRequest
+		}
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		err = &attemptError{err: fmt.Errorf("%w: status %d", ErrInternalServer, resp.StatusCode), retry: retry}
+		return nil, retryAfter(resp.Header.Get("Retry-After")), err
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, 0, &attemptError{err: fmt.Errorf("%w: %w", ErrUnreachable, err), retry: true}
+	}
+	res, err := a.mapping.decode(body)
+	if err != nil {
+		return nil, 0, err
+	}
+	return res, 0, nil
+}
+
+// attemptError is a failed attempt that may succeed if it is repeated.
+type attemptError struct {
+	err   error
+	retry bool
+}
+
+func (e *attemptError) Error() string {
+	return e.err.Error()
+}
+
+func (e *attemptError) Unwrap() error {
+	return e.err
+}
+
+// retryable reports whether a failed attempt is worth repeating.
+func retryable(err error) bool {
+	var attemptErr *attemptError
+	return errors.As(err, &attemptErr) && attemptErr.retry
+}
+
+// backoff returns the delay before the retry following the attempt: the base
+// delay doubled for every attempt and capped at the maximum. The result is
+// picked at random from the upper half of that delay, so that clients don't
+// retry in lockstep.
+func (a *ApiClient) backoff(attempt int) time.Duration {
+	delay := a.backoffBase
+	for i := 0; i < attempt && delay < a.backoffMax; i++ {
+		delay *= 2
+	}
+	if a.backoffMax > 0 {
+		delay = min(delay, a.backoffMax)
+	}
+	if delay <= 0 {
+		return delay
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int64N(int64(delay-half)))
+}
+
+// retryAfter parses a Retry-After header given either in seconds or as an
+// HTTP date. It returns zero if the header is missing or invalid.
+func retryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return 0
 }
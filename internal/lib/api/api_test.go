@@ -0,0 +1,226 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// reply is a scripted response of the test API.
+type reply struct {
+	status     int
+	retryAfter string
+}
+
+// testAPI answers with its replies in turn, repeating the last one, and
+// counts the requests.
+type testAPI struct {
+	mu      sync.Mutex
+	replies []reply
+	calls   int
+}
+
+func (a *testAPI) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	a.mu.Lock()
+	r := a.replies[min(a.calls, len(a.replies)-1)]
+	a.calls++
+	a.mu.Unlock()
+
+	if r.retryAfter != "" {
+		w.Header().Set("Retry-After", r.retryAfter)
+	}
+	w.WriteHeader(r.status)
+	if r.status == http.StatusOK {
+		w.Write([]byte(`{"releaseDate": "16.07.2006", "text": "Ooh baby", "link": "https://example.com"}`))
+	}
+}
+
+func (a *testAPI) script(replies ...reply) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.replies = replies
+	a.calls = 0
+}
+
+func (a *testAPI) requests() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.calls
+}
+
+// newTestClient returns a client of a test API that retries quickly.
+func newTestClient(t *testing.T, opts Options) (*ApiClient, *testAPI) {
+	t.Helper()
+	api := &testAPI{replies: []reply{{status: http.StatusOK}}}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	if opts.Timeout == 0 {
+		opts.Timeout = time.Second
+	}
+	if opts.BackoffBase == 0 {
+		opts.BackoffBase = time.Millisecond
+	}
+	if opts.BackoffMax == 0 {
+		opts.BackoffMax = 50 * time.Millisecond
+	}
+	return NewApiClient(server.URL, server.Client(), opts), api
+}
+
+var testRequest = &models.CreateSongRequest{Title: "Supermassive Black Hole", Group: "Muse"}
+
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      Options
+		replies   []reply
+		wantErr   error
+		wantCalls int
+	}{
+		{
+			name:      "success",
+			opts:      Options{MaxRetries: 3},
+			replies:   []reply{{status: http.StatusOK}},
+			wantCalls: 1,
+		},
+		{
+			name:      "retry on 5xx",
+			opts:      Options{MaxRetries: 3},
+			replies:   []reply{{status: http.StatusInternalServerError}, {status: http.StatusBadGateway}, {status: http.StatusOK}},
+			wantCalls: 3,
+		},
+		{
+			name:      "retry on 429",
+			opts:      Options{MaxRetries: 3},
+			replies:   []reply{{status: http.StatusTooManyRequests, retryAfter: "0"}, {status: http.StatusOK}},
+			wantCalls: 2,
+		},
+		{
+			name:      "retries used up",
+			opts:      Options{MaxRetries: 2},
+			replies:   []reply{{status: http.StatusServiceUnavailable}},
+			wantErr:   ErrInternalServer,
+			wantCalls: 3,
+		},
+		{
+			name:      "no retry on 400",
+			opts:      Options{MaxRetries: 3},
+			replies:   []reply{{status: http.StatusBadRequest}, {status: http.StatusOK}},
+			wantErr:   ErrBadRequest,
+			wantCalls: 1,
+		},
+		{
+			name:      "no retry on other 4xx",
+			opts:      Options{MaxRetries: 3},
+			replies:   []reply{{status: http.StatusNotFound}, {status: http.StatusOK}},
+			wantErr:   ErrInternalServer,
+			wantCalls: 1,
+		},
+		{
+			name:      "Retry-After longer than the maximum backoff",
+			opts:      Options{MaxRetries: 3},
+			replies:   []reply{{status: http.StatusServiceUnavailable, retryAfter: "120"}, {status: http.StatusOK}},
+			wantErr:   ErrInternalServer,
+			wantCalls: 1,
+		},
+		{
+			name:      "retry after the deadline",
+			opts:      Options{MaxRetries: 3, Deadline: 100 * time.Millisecond, BackoffBase: time.Hour, BackoffMax: time.Hour},
+			replies:   []reply{{status: http.StatusInternalServerError}, {status: http.StatusOK}},
+			wantErr:   ErrInternalServer,
+			wantCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, api := newTestClient(t, tt.opts)
+			api.script(tt.replies...)
+
+			song, err := client.GetMoreAboutSong(context.Background(), testRequest)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			} else if song.Text != "Ooh baby" || song.Title != testRequest.Title {
+				t.Errorf("song = %+v", song)
+			}
+			if calls := api.requests(); calls != tt.wantCalls {
+				t.Errorf("requests = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "", want: 0},
+		{value: "3", want: 3 * time.Second},
+		{value: "-3", want: 0},
+		{value: "soon", want: 0},
+		{value: "Wed, 21 Oct 2015 07:28:00 GMT", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := retryAfter(tt.value); got != tt.want {
+				t.Errorf("retryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if got := retryAfter(date); got < 59*time.Minute || got > time.Hour {
+		t.Errorf("retryAfter(%q) = %v, want about an hour", date, got)
+	}
+}
+
+func TestClientBreaker(t *testing.T) {
+	client, api := newTestClient(t, Options{BreakerThreshold: 2, BreakerCooldown: time.Minute})
+	ctx := context.Background()
+
+	api.script(reply{status: http.StatusInternalServerError})
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetMoreAboutSong(ctx, testRequest); !errors.Is(err, ErrInternalServer) {
+			t.Fatalf("failure %d: error = %v, want %v", i+1, err, ErrInternalServer)
+		}
+	}
+	if _, err := client.GetMoreAboutSong(ctx, testRequest); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("open: error = %v, want %v", err, ErrCircuitOpen)
+	}
+	if calls := api.requests(); calls != 2 {
+		t.Errorf("open: requests = %d, want 2", calls)
+	}
+
+	// A song the API doesn't know is an answer, so the trial closes the breaker.
+	client.breaker.openedAt = time.Now().Add(-time.Minute)
+	api.script(reply{status: http.StatusBadRequest})
+	if _, err := client.GetMoreAboutSong(ctx, testRequest); !errors.Is(err, ErrBadRequest) {
+		t.Fatalf("trial: error = %v, want %v", err, ErrBadRequest)
+	}
+	if state := client.Status()[0].State; state != BreakerClosed {
+		t.Errorf("after the trial: state = %q, want %q", state, BreakerClosed)
+	}
+
+	// Calls cancelled by the caller don't count as failures.
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	api.script(reply{status: http.StatusInternalServerError})
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetMoreAboutSong(cancelled, testRequest); !errors.Is(err, context.Canceled) {
+			t.Fatalf("cancelled call: error = %v, want %v", err, context.Canceled)
+		}
+	}
+	if status := client.Status()[0]; status.State != BreakerClosed || status.ConsecutiveFailures != 0 {
+		t.Errorf("after cancelled calls: status = %+v", status)
+	}
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"sync"
+	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// States of the circuit breaker.
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half-open"
+)
+
+// Breaker stops calling the API after threshold consecutive failures. Once
+// cooldown has passed, a single trial call is let through: its success closes
+// the breaker again, its failure reopens it for another cooldown.
+type Breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	state     string
+	failures  int
+	openedAt  time.Time
+	lastError string
+	trial     bool
+}
+
+// NewBreaker creates a closed breaker. A threshold of zero disables it.
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		state:     BreakerClosed,
+	}
+}
+
+// Allow reports whether a call may be made now. Every allowed call must be
+// followed by Success or Failure.
+func (b *Breaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if time.Now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = BreakerHalfOpen
+		b.trial = true
+		return true
+	case BreakerHalfOpen:
+		// Only one trial call at a time.
+		if b.trial {
+			return false
+		}
+		b.trial = true
+		return true
+	default:
+		return true
+	}
+}
+
+// Success records a successful call and closes the breaker.
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = BreakerClosed
+	b.failures = 0
+	b.trial = false
+}
+
+// Failure records a failed call and opens the breaker once there were too
+// many failures in a row, or if the trial call failed.
+func (b *Breaker) Failure(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.trial = false
+	if err != nil {
+		b.lastError = err.Error()
+	}
+	if b.threshold > 0 && (b.state == BreakerHalfOpen || b.failures >= b.threshold) {
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// Ignore records a call whose outcome says nothing about the API, e.g. one
+// cancelled by the caller.
+func (b *Breaker) Ignore() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// In the half-open state the next call becomes the trial.
+	b.trial = false
+}
+
+// Status describes the current state of the breaker.
+func (b *Breaker) Status() models.UpstreamStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := models.UpstreamStatus{
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		LastError:           b.lastError,
+	}
+	if b.state == BreakerOpen {
+		retryAt := b.openedAt.Add(b.cooldown)
+		status.RetryAt = &retryAt
+	}
+	return status
+}
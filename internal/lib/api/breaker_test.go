@@ -0,0 +1,76 @@
+package api
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBreaker(t *testing.T) {
+	b := NewBreaker(2, time.Minute)
+	errAPI := errors.New("status 500")
+
+	// expire ends the cooldown of the open breaker without waiting for it.
+	expire := func() {
+		b.openedAt = time.Now().Add(-time.Minute)
+	}
+	wantState := func(step, state string) {
+		t.Helper()
+		if got := b.Status().State; got != state {
+			t.Fatalf("%s: state = %q, want %q", step, got, state)
+		}
+	}
+
+	b.Failure(errAPI)
+	wantState("one failure", BreakerClosed)
+	if !b.Allow() {
+		t.Fatalf("one failure: call rejected")
+	}
+	b.Failure(errAPI)
+	wantState("threshold reached", BreakerOpen)
+	if b.Allow() {
+		t.Fatalf("open: call allowed")
+	}
+	if status := b.Status(); status.RetryAt == nil || status.LastError != errAPI.Error() || status.ConsecutiveFailures != 2 {
+		t.Errorf("open: status = %+v", status)
+	}
+
+	expire()
+	if !b.Allow() {
+		t.Fatalf("after cooldown: trial call rejected")
+	}
+	wantState("trial", BreakerHalfOpen)
+	if b.Allow() {
+		t.Fatalf("trial: second call allowed")
+	}
+	b.Failure(errAPI)
+	wantState("failed trial", BreakerOpen)
+
+	expire()
+	if !b.Allow() {
+		t.Fatalf("after second cooldown: trial call rejected")
+	}
+	b.Ignore()
+	wantState("ignored trial", BreakerHalfOpen)
+	if !b.Allow() {
+		t.Fatalf("ignored trial: next trial call rejected")
+	}
+	b.Success()
+	wantState("successful trial", BreakerClosed)
+	if status := b.Status(); status.RetryAt != nil || status.ConsecutiveFailures != 0 {
+		t.Errorf("closed: status = %+v", status)
+	}
+	if !b.Allow() {
+		t.Fatalf("closed: call rejected")
+	}
+}
+
+func TestBreakerDisabled(t *testing.T) {
+	b := NewBreaker(0, time.Minute)
+	for i := 0; i < 10; i++ {
+		b.Failure(errors.New("status 500"))
+	}
+	if !b.Allow() {
+		t.Errorf("call rejected by a disabled breaker")
+	}
+}
@@ -0,0 +1,196 @@
+package api
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// Client is implemented by ApiClient and the decorators around it.
+type Client interface {
+	GetMoreAboutSong(ctx context.Context, req *models.CreateSongRequest) (*models.Song, error)
+	Status() []models.UpstreamStatus
+}
+
+// CacheStore keeps cached answers across restarts. ReadCachedSongInfo returns
+// nil if the key is missing or has expired.
+type CacheStore interface {
+	ReadCachedSongInfo(ctx context.Context, key string) (*models.CachedSongInfo, error)
+	SaveCachedSongInfo(ctx context.Context, info *models.CachedSongInfo) error
+}
+
+// CacheOptions configure the cache of song details.
+type CacheOptions struct {
+	TTL         time.Duration // How long found songs are cached.
+	NegativeTTL time.Duration // How long songs rejected by the API are cached; 0 disables it.
+	MaxEntries  int           // Songs kept in memory, the least recently used are evicted first; 0 means no limit.
+	Store       CacheStore    // Optional persistent store, consulted on a miss in memory.
+}
+
+// Cache remembers the answers of the client for songs asked about before,
+// keyed by the normalised title and group. Answers rejected with
+// ErrBadRequest are cached too, so unknown songs don't hit the API again.
+// Failures of the persistent store are logged and otherwise ignored.
+type Cache struct {
+	client Client
+	log    *slog.Logger
+	opts   CacheOptions
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // Front is the most recently used entry.
+}
+
+func NewCache(client Client, log *slog.Logger, opts CacheOptions) *Cache {
+	return &Cache{
+		client:  client,
+		log:     log,
+		opts:    opts,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// bypassCacheKey marks contexts of requests that skip the cached answers.
+type bypassCacheKey struct{}
+
+// BypassCache returns a context that makes Cache ask the client even if the
+// song is cached. The fresh answer still replaces the cached one.
+func BypassCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassCacheKey{}, true)
+}
+
+// GetMoreAboutSong returns the cached details of the song, asking the client
+// only if they aren't cached or have expired, or if ctx comes from BypassCache.
+func (c *Cache) GetMoreAboutSong(ctx context.Context, req *models.CreateSongRequest) (*models.Song, error) {
+	const op = "api.Cache.GetMoreAboutSong"
+	key := CacheKey(req.Title, req.Group)
+
+	var info *models.CachedSongInfo
+	if bypass, _ := ctx.Value(bypassCacheKey{}).(bool); !bypass {
+		info = c.lookup(ctx, key)
+	}
+	if info != nil {
+		c.log.Debug("song info found in cache", slog.String("key", key))
+		if info.NotFound {
+			return nil, fmt.Errorf("%s: %w (cached)", op, ErrBadRequest)
+		}
+		return &models.Song{
+			Title:       req.Title,
+			Group:       req.Group,
+			ReleaseDate: info.ReleaseDate,
+			Text:        info.Text,
+			Link:        info.Link,
+			Sources:     info.Sources,
+		}, nil
+	}
+
+	song, err := c.client.GetMoreAboutSong(ctx, req)
+	switch {
+	case err == nil:
+		c.save(ctx, &models.CachedSongInfo{
+			Key:         key,
+			ReleaseDate: song.ReleaseDate,
+			Text:        song.Text,
+			Link:        song.Link,
+			Sources:     song.Sources,
+			ExpiresAt:   time.Now().Add(c.opts.TTL),
+		})
+	case errors.Is(err, ErrBadRequest) && c.opts.NegativeTTL > 0:
+		c.save(ctx, &models.CachedSongInfo{
+			Key:       key,
+			NotFound:  true,
+			ExpiresAt: time.Now().Add(c.opts.NegativeTTL),
+		})
+	}
+	return song, err
+}
+
+// Status reports the state of the wrapped client.
+func (c *Cache) Status() []models.UpstreamStatus {
+	return c.client.Status()
+}
+
+// CacheKey normalises the title and group, so that songs differing only in
+// case or spacing share an entry.
+func CacheKey(title, group string) string {
+	normalise := func(s string) string {
+		return strings.ToLower(strings.Join(strings.Fields(s), " "))
+	}
+	// Fields never contain tabs after normalisation.
+	return normalise(group) + "\t" + normalise(title)
+}
+
+// lookup returns the unexpired entry from memory or, on a miss, from the
+// persistent store, or nil.
+func (c *Cache) lookup(ctx context.Context, key string) *models.CachedSongInfo {
+	info := c.get(key)
+	if info != nil || c.opts.Store == nil {
+		return info
+	}
+	info, err := c.opts.Store.ReadCachedSongInfo(ctx, key)
+	if err != nil {
+		c.log.Warn("failed to read cached song info", sl.Error(err))
+	}
+	if info != nil {
+		c.put(info)
+	}
+	return info
+}
+
+// get returns the unexpired entry from memory, or nil.
+func (c *Cache) get(key string) *models.CachedSongInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	info := elem.Value.(*models.CachedSongInfo)
+	if !time.Now().Before(info.ExpiresAt) {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+		return nil
+	}
+	c.lru.MoveToFront(elem)
+	return info
+}
+
+// put adds the entry to memory, evicting the least recently used entries
+// beyond MaxEntries.
+func (c *Cache) put(info *models.CachedSongInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[info.Key]; ok {
+		elem.Value = info
+		c.lru.MoveToFront(elem)
+	} else {
+		c.entries[info.Key] = c.lru.PushFront(info)
+	}
+	for c.opts.MaxEntries > 0 && c.lru.Len() > c.opts.MaxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*models.CachedSongInfo).Key)
+	}
+}
+
+// save caches the entry in memory and in the persistent store.
+func (c *Cache) save(ctx context.Context, info *models.CachedSongInfo) {
+	c.put(info)
+	if c.opts.Store == nil {
+		return
+	}
+	if err := c.opts.Store.SaveCachedSongInfo(ctx, info); err != nil {
+		c.log.Warn("failed to save song info to cache", sl.Error(err))
+	}
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// countingClient answers with the text it is set to and counts the calls.
+type countingClient struct {
+	text  string
+	calls int
+}
+
+func (c *countingClient) GetMoreAboutSong(_ context.Context, req *models.CreateSongRequest) (*models.Song, error) {
+	c.calls++
+	return &models.Song{Title: req.Title, Group: req.Group, Text: c.text}, nil
+}
+
+func (c *countingClient) Status() []models.UpstreamStatus {
+	return nil
+}
+
+func TestCacheBypass(t *testing.T) {
+	client := &countingClient{text: "old"}
+	cache := NewCache(client, slog.New(slog.NewTextHandler(io.Discard, nil)), CacheOptions{TTL: time.Hour})
+	req := &models.CreateSongRequest{Title: "Uprising", Group: "Muse"}
+
+	steps := []struct {
+		name      string
+		ctx       context.Context
+		upstream  string
+		wantText  string
+		wantCalls int
+	}{
+		{name: "miss", ctx: context.Background(), upstream: "old", wantText: "old", wantCalls: 1},
+		{name: "hit", ctx: context.Background(), upstream: "new", wantText: "old", wantCalls: 1},
+		{name: "bypass", ctx: BypassCache(context.Background()), upstream: "new", wantText: "new", wantCalls: 2},
+		{name: "hit after bypass", ctx: context.Background(), upstream: "newer", wantText: "new", wantCalls: 2},
+	}
+	for _, step := range steps {
+		client.text = step.upstream
+		song, err := cache.GetMoreAboutSong(step.ctx, req)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", step.name, err)
+		}
+		if song.Text != step.wantText {
+			t.Errorf("%s: text = %q, want %q", step.name, song.Text, step.wantText)
+		}
+		if client.calls != step.wantCalls {
+			t.Errorf("%s: calls = %d, want %d", step.name, client.calls, step.wantCalls)
+		}
+	}
+}
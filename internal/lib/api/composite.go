@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// Provider is a named source of song details.
+type Provider struct {
+	Name   string
+	Client Client
+}
+
+// Composite asks several providers for the details of a song and merges
+// their answers: every field is taken from the first provider, in priority
+// order, that has it. Providers that fail or don't know the song are skipped.
+// The provider of every field is recorded in the sources of the song.
+type Composite struct {
+	providers []Provider
+	parallel  bool
+	deadline  time.Duration
+}
+
+// NewComposite creates a client over the providers, given in priority order.
+// With parallel all providers are asked at once, otherwise the next provider
+// is only asked while some field is still missing. The deadline bounds a
+// whole call over all providers; 0 means none.
+func NewComposite(providers []Provider, parallel bool, deadline time.Duration) *Composite {
+	return &Composite{
+		providers: providers,
+		parallel:  parallel,
+		deadline:  deadline,
+	}
+}
+
+// result is the answer of one provider.
+type result struct {
+	song *models.Song
+	err  error
+}
+
+// GetMoreAboutSong merges the answers of the providers. It fails with
+// ErrBadRequest if every provider rejected the song, and with the errors of
+// the failed providers if none of them answered.
+func (c *Composite) GetMoreAboutSong(ctx context.Context, req *models.CreateSongRequest) (*models.Song, error) {
+	const op = "api.Composite.GetMoreAboutSong"
+	if c.deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.deadline)
+		defer cancel()
+	}
+
+	res := &models.Song{Title: req.Title, Group: req.Group, Sources: models.Sources{}}
+	results := make([]result, len(c.providers))
+	if c.parallel {
+		var wg sync.WaitGroup
+		for i, p := range c.providers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				song, err := p.Client.GetMoreAboutSong(ctx, req)
+				results[i] = result{song: song, err: err}
+			}()
+		}
+		wg.Wait()
+	}
+
+	answered := false
+	var errs []error
+	for i, p := range c.providers {
+		if !c.parallel {
+			if complete(res) {
+				break
+			}
+			song, err := p.Client.GetMoreAboutSong(ctx, req)
+			results[i] = result{song: song, err: err}
+		}
+
+		r := results[i]
+		if r.err != nil {
+			if !errors.Is(r.err, ErrBadRequest) {
+				errs = append(errs, fmt.Errorf("%s: %w", p.Name, r.err))
+			}
+			continue
+		}
+		answered = true
+		merge(res, r.song, p.Name)
+	}
+
+	if !answered {
+		if len(errs) == 0 {
+			return nil, fmt.Errorf("%s: %w", op, ErrBadRequest)
+		}
+		return nil, fmt.Errorf("%s: %w", op, errors.Join(errs...))
+	}
+	return res, nil
+}
+
+// Status reports the state of every provider.
+func (c *Composite) Status() []models.UpstreamStatus {
+	var status []models.UpstreamStatus
+	for _, p := range c.providers {
+		for _, s := range p.Client.Status() {
+			s.Name = p.Name + " (" + s.Name + ")"
+			status = append(status, s)
+		}
+	}
+	return status
+}
+
+// merge fills the fields of dst that are still empty from src.
+func merge(dst, src *models.Song, provider string) {
+	if dst.ReleaseDate.IsZero() && !src.ReleaseDate.IsZero() {
+		dst.ReleaseDate = src.ReleaseDate
+		dst.Sources[models.FieldReleaseDate] = provider
+	}
+	if dst.Text == "" && src.Text != "" {
+		dst.Text = src.Text
+		dst.Sources[models.FieldText] = provider
+	}
+	if dst.Link == "" && src.Link != "" {
+		dst.Link = src.Link
+		dst.Sources[models.FieldLink] = provider
+	}
+}
+
+// complete reports whether all details of the song are known.
+func complete(song *models.Song) bool {
+	return !song.ReleaseDate.IsZero() && song.Text != "" && song.Link != ""
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+var (
+	ErrInvalidResponse = errors.New("invalid api response")
+)
+
+// ResponseMapping tells where the song details are in the JSON response of
+// the API. Fields are dot-separated paths, e.g. "data.lyrics.body" or
+// "tracks.0.url"; empty fields use the names of the original API. A path
+// that isn't present in the response leaves the field empty.
+type ResponseMapping struct {
+	ReleaseDate       string
+	Text              string
+	Link              string
+	ReleaseDateLayout string // Go time layout; by default RFC 3339, 2006-01-02 and 02.01.2006 are accepted.
+}
+
+// Defaults of ResponseMapping.
+const (
+	DefaultReleaseDatePath = "releaseDate"
+	DefaultTextPath        = "text"
+	DefaultLinkPath        = "link"
+)
+
+// defaultDateLayouts are tried in order when no layout is configured.
+var defaultDateLayouts = []string{time.RFC3339, time.DateOnly, "02.01.2006"}
+
+// withDefaults fills empty fields of the mapping with their defaults.
+func (m ResponseMapping) withDefaults() ResponseMapping {
+	if m.ReleaseDate == "" {
+		m.ReleaseDate = DefaultReleaseDatePath
+	}
+	if m.Text == "" {
+		m.Text = DefaultTextPath
+	}
+	if m.Link == "" {
+		m.Link = DefaultLinkPath
+	}
+	return m
+}
+
+// decode extracts the song details from the response body.
+func (m ResponseMapping) decode(body []byte) (*models.Song, error) {
+	const op = "api.decode"
+
+	var doc any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrInvalidResponse, err)
+	}
+
+	var song models.Song
+	var err error
+	if song.Text, err = lookup(doc, m.Text); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, m.Text, err)
+	}
+	if song.Link, err = lookup(doc, m.Link); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, m.Link, err)
+	}
+	date, err := lookup(doc, m.ReleaseDate)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, m.ReleaseDate, err)
+	}
+	if date != "" {
+		if song.ReleaseDate, err = m.parseDate(date); err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", op, m.ReleaseDate, err)
+		}
+	}
+	return &song, nil
+}
+
+// parseDate parses the release date with the configured or default layouts.
+func (m ResponseMapping) parseDate(value string) (time.Time, error) {
+	layouts := defaultDateLayouts
+	if m.ReleaseDateLayout != "" {
+		layouts = []string{m.ReleaseDateLayout}
+	}
+	for _, layout := range layouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: unknown date format %q", ErrInvalidResponse, value)
+}
+
+// lookup returns the value at the dot-separated path as a string. Numbers
+// and booleans are formatted, while objects and arrays are rejected.
+func lookup(doc any, path string) (string, error) {
+	for _, key := range strings.Split(path, ".") {
+		switch node := doc.(type) {
+		case map[string]any:
+			doc = node[key]
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return "", nil
+			}
+			doc = node[i]
+		default:
+			return "", nil
+		}
+	}
+
+	switch value := doc.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	default:
+		return "", fmt.Errorf("%w: expected a string", ErrInvalidResponse)
+	}
+}
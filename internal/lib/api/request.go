@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// RequestTemplate describes how the song info request is built, so that the
+// client can talk to differently shaped APIs. Empty fields use the defaults
+// of the original API: GET {url}/info?song=...&group=...
+type RequestTemplate struct {
+	Path         string            // Path of the endpoint relative to the API URL, optionally with a query.
+	TitleParam   string            // Query parameter with the song title.
+	GroupParam   string            // Query parameter with the group name.
+	Headers      map[string]string // Extra headers sent with every request.
+	APIKey       string            // Key sent in APIKeyHeader, or in APIKeyParam if it is set.
+	APIKeyHeader string            // Header carrying the key, X-Api-Key by default.
+	APIKeyParam  string            // Query parameter carrying the key instead of a header.
+}
+
+// Defaults of RequestTemplate.
+const (
+	DefaultPath         = "/info"
+	DefaultTitleParam   = "song"
+	DefaultGroupParam   = "group"
+	DefaultAPIKeyHeader = "X-Api-Key"
+)
+
+// withDefaults fills empty fields of the template with their defaults.
+func (t RequestTemplate) withDefaults() RequestTemplate {
+	if t.Path == "" {
+		t.Path = DefaultPath
+	}
+	if t.TitleParam == "" {
+		t.TitleParam = DefaultTitleParam
+	}
+	if t.GroupParam == "" {
+		t.GroupParam = DefaultGroupParam
+	}
+	if t.APIKeyHeader == "" {
+		t.APIKeyHeader = DefaultAPIKeyHeader
+	}
+	return t
+}
+
+// newRequest builds the song info request. The query is encoded with
+// net/url, so titles with &, #, spaces or non-ASCII characters are sent as is.
+func (a *ApiClient) newRequest(ctx context.Context, req *models.CreateSongRequest) (*http.Request, error) {
+	const op = "api.newRequest"
+
+	base, err := url.Parse(a.ApiURL)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	// The path may carry fixed query parameters, e.g. /search?format=json.
+	endpoint, err := url.Parse(a.request.Path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	u := base.JoinPath(endpoint.Path)
+
+	query := u.Query()
+	for key, values := range endpoint.Query() {
+		query[key] = values
+	}
+	query.Set(a.request.TitleParam, req.Title)
+	query.Set(a.request.GroupParam, req.Group)
+	if a.request.APIKey != "" && a.request.APIKeyParam != "" {
+		query.Set(a.request.APIKeyParam, a.request.APIKey)
+	}
+	u.RawQuery = query.Encode()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	for key, value := range a.request.Headers {
+		httpReq.Header.Set(key, value)
+	}
+	if a.request.APIKey != "" && a.request.APIKeyParam == "" {
+		httpReq.Header.Set(a.request.APIKeyHeader, a.request.APIKey)
+	}
+	return httpReq, nil
+}
@@ -0,0 +1,254 @@
+package chordpro
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+var (
+	ErrInvalid = errors.New("invalid ChordPro document")
+)
+
+// SectionTab is the type of sections whose lines are kept verbatim.
+const SectionTab = "tab"
+
+// Short forms of the directives.
+var directiveAliases = map[string]string{
+	"t":   "title",
+	"st":  "subtitle",
+	"c":   "comment",
+	"ci":  "comment",
+	"cb":  "comment",
+	"soc": "start_of_chorus",
+	"eoc": "end_of_chorus",
+	"sov": "start_of_verse",
+	"eov": "end_of_verse",
+	"sob": "start_of_bridge",
+	"eob": "end_of_bridge",
+	"sot": "start_of_tab",
+	"eot": "end_of_tab",
+	"sog": "start_of_grid",
+	"eog": "end_of_grid",
+}
+
+// parser holds the state of Parse.
+type parser struct {
+	sheet   models.ChordSheet
+	current *models.ChordSection
+	env     string // Name of the open start_of_ section, or empty.
+}
+
+// Parse reads a ChordPro document: lyrics with chords in brackets, like
+// "[G]Hello [C/E]world", and directives in braces, like "{title: Song}" or
+// "{start_of_chorus}". Lines starting with # are comments. Unknown
+// directives are ignored. Lines outside of sections are grouped into
+// sections separated by blank lines.
+func Parse(source string) (*models.ChordSheet, error) {
+	var p parser
+	for n, line := range strings.Split(source, "\n") {
+		if err := p.line(strings.TrimRight(line, " \t\r")); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalid, n+1, err)
+		}
+	}
+	if p.env != "" {
+		return nil, fmt.Errorf("%w: missing end_of_%s", ErrInvalid, p.env)
+	}
+	p.flush()
+	if p.sheet.Sections == nil {
+		p.sheet.Sections = []models.ChordSection{}
+	}
+	p.sheet.Lyrics = Lyrics(&p.sheet)
+	return &p.sheet, nil
+}
+
+func (p *parser) line(line string) error {
+	trimmed := strings.TrimSpace(line)
+
+	// Tabs and grids are kept verbatim until their end.
+	if p.env == SectionTab || p.env == "grid" {
+		if name, _, ok := directive(trimmed); ok && name == "end_of_"+p.env {
+			p.env = ""
+			p.flush()
+			return nil
+		}
+		p.add(models.ChordLine{Tab: line})
+		return nil
+	}
+
+	switch {
+	case strings.HasPrefix(trimmed, "#"):
+		return nil
+	case trimmed == "":
+		if p.env == "" {
+			p.flush()
+		}
+		return nil
+	case strings.HasPrefix(trimmed, "{"):
+		name, value, ok := directive(trimmed)
+		if !ok {
+			return errors.New("directive is not closed with }")
+		}
+		return p.directive(name, value)
+	}
+
+	segments, err := parseSegments(trimmed)
+	if err != nil {
+		return err
+	}
+	p.add(models.ChordLine{Segments: segments})
+	return nil
+}
+
+func (p *parser) directive(name, value string) error {
+	switch name {
+	case "title":
+		p.sheet.Title = value
+	case "subtitle":
+		p.sheet.Subtitle = value
+	case "artist":
+		p.sheet.Artist = value
+	case "key":
+		p.sheet.Key = value
+	case "capo":
+		capo, err := strconv.Atoi(value)
+		if err != nil || capo < 0 {
+			return fmt.Errorf("invalid capo %q", value)
+		}
+		p.sheet.Capo = capo
+	case "comment":
+		p.add(models.ChordLine{Comment: value})
+	case "chorus":
+		// Repeat the last chorus.
+		p.flush()
+		section := models.ChordSection{Type: models.SectionChorus, Label: value}
+		for i := len(p.sheet.Sections) - 1; i >= 0; i-- {
+			if p.sheet.Sections[i].Type == models.SectionChorus {
+				section.Lines = p.sheet.Sections[i].Lines
+				break
+			}
+		}
+		if section.Label == "" {
+			section.Label = "Chorus"
+		}
+		if section.Lines == nil {
+			section.Lines = []models.ChordLine{}
+		}
+		p.sheet.Sections = append(p.sheet.Sections, section)
+	default:
+		if env, ok := strings.CutPrefix(name, "start_of_"); ok {
+			if p.env != "" {
+				return fmt.Errorf("start_of_%s inside start_of_%s", env, p.env)
+			}
+			p.flush()
+			p.env = env
+			p.current = &models.ChordSection{Type: env, Label: value}
+			return nil
+		}
+		if env, ok := strings.CutPrefix(name, "end_of_"); ok {
+			if env != p.env {
+				return fmt.Errorf("end_of_%s without start_of_%s", env, env)
+			}
+			p.env = ""
+			p.flush()
+		}
+	}
+	return nil
+}
+
+// add appends the line to the current section, starting one if needed.
+func (p *parser) add(line models.ChordLine) {
+	if p.current == nil {
+		p.current = &models.ChordSection{}
+	}
+	p.current.Lines = append(p.current.Lines, line)
+}
+
+// flush ends the current section.
+func (p *parser) flush() {
+	if p.current == nil {
+		return
+	}
+	if p.current.Lines == nil {
+		p.current.Lines = []models.ChordLine{}
+	}
+	if len(p.current.Lines) > 0 || p.current.Type != "" {
+		p.sheet.Sections = append(p.sheet.Sections, *p.current)
+	}
+	p.current = nil
+}
+
+// directive splits "{name: value}" into its name, with aliases resolved, and
+// its value.
+func directive(line string) (name, value string, ok bool) {
+	if !strings.HasPrefix(line, "{") || !strings.HasSuffix(line, "}") {
+		return "", "", false
+	}
+	inner := line[1 : len(line)-1]
+	name, value, found := strings.Cut(inner, ":")
+	if !found {
+		// The value may also follow a space, like in "{title Song}".
+		name, value, _ = strings.Cut(inner, " ")
+	}
+	name = strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := directiveAliases[name]; ok {
+		name = alias
+	}
+	return name, strings.TrimSpace(value), true
+}
+
+// parseSegments splits a line of lyrics at its chords.
+func parseSegments(line string) ([]models.ChordSegment, error) {
+	var (
+		segments []models.ChordSegment
+		current  models.ChordSegment
+		hasChord bool
+	)
+	for {
+		open := strings.IndexByte(line, '[')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(line[open:], ']')
+		if end < 0 {
+			return nil, errors.New("chord is not closed with ]")
+		}
+		current.Text += line[:open]
+		if hasChord || current.Text != "" {
+			segments = append(segments, current)
+		}
+		current = models.ChordSegment{Chord: strings.TrimSpace(line[open+1 : open+end])}
+		hasChord = true
+		line = line[open+end+1:]
+	}
+	current.Text += line
+	return append(segments, current), nil
+}
+
+// Lyrics returns the text of the sheet without chords, comments and tabs.
+// Sections are separated by blank lines.
+func Lyrics(sheet *models.ChordSheet) string {
+	var sections []string
+	for _, section := range sheet.Sections {
+		var lines []string
+		for _, line := range section.Lines {
+			if len(line.Segments) == 0 {
+				continue
+			}
+			var b strings.Builder
+			for _, segment := range line.Segments {
+				b.WriteString(segment.Text)
+			}
+			if text := strings.Join(strings.Fields(b.String()), " "); text != "" {
+				lines = append(lines, text)
+			}
+		}
+		if len(lines) > 0 {
+			sections = append(sections, strings.Join(lines, "\n"))
+		}
+	}
+	return strings.Join(sections, "\n\n")
+}
@@ -0,0 +1,168 @@
+package chordpro
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+func TestParse(t *testing.T) {
+	source := `{title: Uprising}
+{st: The Resistance}
+{artist: Muse}
+{key: Dm}
+{capo: 2}
+# A comment of the file
+[Dm]Paranoia is in [F]bloom
+The [C]PR
+
+{c: Loud}
+{start_of_chorus: Chorus 1}
+[Dm]They will not [Bb]force us
+{end_of_chorus}
+{chorus}
+{sot}
+e|--0--|
+{eot}`
+
+	line := func(segments ...models.ChordSegment) models.ChordLine {
+		return models.ChordLine{Segments: segments}
+	}
+	chorus := []models.ChordLine{line(
+		models.ChordSegment{Chord: "Dm", Text: "They will not "},
+		models.ChordSegment{Chord: "Bb", Text: "force us"},
+	)}
+	want := &models.ChordSheet{
+		Title:    "Uprising",
+		Subtitle: "The Resistance",
+		Artist:   "Muse",
+		Key:      "Dm",
+		Capo:     2,
+		Sections: []models.ChordSection{
+			{Lines: []models.ChordLine{
+				line(models.ChordSegment{Chord: "Dm", Text: "Paranoia is in "}, models.ChordSegment{Chord: "F", Text: "bloom"}),
+				line(models.ChordSegment{Text: "The "}, models.ChordSegment{Chord: "C", Text: "PR"}),
+			}},
+			{Lines: []models.ChordLine{{Comment: "Loud"}}},
+			{Type: models.SectionChorus, Label: "Chorus 1", Lines: chorus},
+			{Type: models.SectionChorus, Label: "Chorus", Lines: chorus},
+			{Type: SectionTab, Lines: []models.ChordLine{{Tab: "e|--0--|"}}},
+		},
+		Lyrics: "Paranoia is in bloom\nThe PR\n\nThey will not force us\n\nThey will not force us",
+	}
+
+	got, err := Parse(source)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+	}{
+		{name: "chord not closed", source: "[G Hello"},
+		{name: "directive not closed", source: "{title: Song"},
+		{name: "invalid capo", source: "{capo: two}"},
+		{name: "negative capo", source: "{capo: -1}"},
+		{name: "nested sections", source: "{soc}\n{sov}\n{eov}\n{eoc}"},
+		{name: "end without start", source: "{eoc}"},
+		{name: "end of another section", source: "{soc}\n{eov}"},
+		{name: "missing end", source: "{sov}\n[G]Hello"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.source); !errors.Is(err, ErrInvalid) {
+				t.Errorf("error = %v, want %v", err, ErrInvalid)
+			}
+		})
+	}
+}
+
+func TestTransposeChord(t *testing.T) {
+	tests := []struct {
+		chord     string
+		semitones int
+		spelling  []string
+		want      string
+	}{
+		{chord: "C", semitones: 2, want: "D"},
+		{chord: "C", semitones: 1, want: "C#"},
+		{chord: "D", semitones: -1, want: "Db"},
+		{chord: "Bb", semitones: 2, want: "C"},
+		{chord: "Bb", semitones: 1, want: "B"},
+		{chord: "F#m7", semitones: 1, want: "Gm7"},
+		{chord: "Cmaj7", semitones: -2, want: "Bbmaj7"},
+		{chord: "D/F#", semitones: 2, want: "E/G#"},
+		{chord: "C6/9", semitones: 2, want: "D6/9"},
+		{chord: "Asus4", semitones: 12, want: "Asus4"},
+		{chord: "G", semitones: -13, want: "Gb"},
+		{chord: "Am", semitones: 3, spelling: sharpNames, want: "Cm"},
+		{chord: "C#", semitones: 1, spelling: flatNames, want: "D"},
+		{chord: "C", semitones: 3, spelling: flatNames, want: "Eb"},
+		{chord: "N.C.", semitones: 3, want: "N.C."},
+		{chord: "Coda", semitones: 3, want: "Coda"},
+		{chord: "", semitones: 3, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.chord, func(t *testing.T) {
+			if got := TransposeChord(tt.chord, tt.semitones, tt.spelling); got != tt.want {
+				t.Errorf("TransposeChord(%q, %d) = %q, want %q", tt.chord, tt.semitones, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	tests := []struct {
+		name       string
+		key        string
+		semitones  int
+		wantKey    string
+		wantChords []string
+	}{
+		{name: "into a flat key", key: "G", semitones: 1, wantKey: "Ab", wantChords: []string{"Ab", "Eb/Bb", "N.C."}},
+		{name: "into a sharp key", key: "G", semitones: -1, wantKey: "F#", wantChords: []string{"F#", "C#/G#", "N.C."}},
+		{name: "minor key", key: "Em", semitones: 3, wantKey: "Gm", wantChords: []string{"Bb", "F/C", "N.C."}},
+		{name: "unknown key", key: "", semitones: 1, wantKey: "", wantChords: []string{"G#", "D#/A#", "N.C."}},
+		{name: "whole octave", key: "G", semitones: -12, wantKey: "G", wantChords: []string{"G", "D/A", "N.C."}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sheet := &models.ChordSheet{
+				Key:       tt.key,
+				Transpose: 1,
+				Sections: []models.ChordSection{{Lines: []models.ChordLine{
+					{Segments: []models.ChordSegment{{Chord: "G", Text: "Hello "}, {Chord: "D/A", Text: "world"}}},
+					{Comment: "Loud"},
+					{Segments: []models.ChordSegment{{Chord: "N.C.", Text: "Stop"}}},
+				}}},
+			}
+			got := Transpose(sheet, tt.semitones)
+
+			if got.Key != tt.wantKey {
+				t.Errorf("key = %q, want %q", got.Key, tt.wantKey)
+			}
+			if got.Transpose != 1+tt.semitones {
+				t.Errorf("transpose = %d, want %d", got.Transpose, 1+tt.semitones)
+			}
+			lines := got.Sections[0].Lines
+			chords := []string{lines[0].Segments[0].Chord, lines[0].Segments[1].Chord, lines[2].Segments[0].Chord}
+			if !reflect.DeepEqual(chords, tt.wantChords) {
+				t.Errorf("chords = %q, want %q", chords, tt.wantChords)
+			}
+			if lines[1].Comment != "Loud" {
+				t.Errorf("comment = %q, want %q", lines[1].Comment, "Loud")
+			}
+			if sheet.Sections[0].Lines[0].Segments[0].Chord != "G" {
+				t.Errorf("the original sheet has changed")
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package chordpro
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// Formats of rendered chord sheets.
+const (
+	FormatJSON     = "json"
+	FormatChordPro = "chordpro"
+	FormatText     = "text"
+	FormatHTML     = "html"
+)
+
+// Text renders the sheet as plain text with every line of chords above the
+// lyrics sung on them. Lyrics are padded where chords would overlap.
+func Text(sheet *models.ChordSheet) string {
+	var b strings.Builder
+	header := textHeader(sheet)
+	for _, line := range header {
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	for i, section := range sheet.Sections {
+		if i > 0 || len(header) > 0 {
+			b.WriteByte('\n')
+		}
+		if label := sectionLabel(section); label != "" {
+			b.WriteString(label)
+			b.WriteString(":\n")
+		}
+		for _, line := range section.Lines {
+			switch {
+			case line.Comment != "":
+				fmt.Fprintf(&b, "(%s)\n", line.Comment)
+			case len(line.Segments) == 0:
+				b.WriteString(line.Tab)
+				b.WriteByte('\n')
+			default:
+				chords, lyrics := textLine(line.Segments)
+				if chords != "" {
+					b.WriteString(chords)
+					b.WriteByte('\n')
+				}
+				if lyrics != "" {
+					b.WriteString(lyrics)
+					b.WriteByte('\n')
+				}
+			}
+		}
+	}
+	return b.String()
+}
+
+func textHeader(sheet *models.ChordSheet) []string {
+	var header []string
+	for _, s := range []string{sheet.Title, sheet.Subtitle, sheet.Artist} {
+		if s != "" {
+			header = append(header, s)
+		}
+	}
+	if sheet.Key != "" {
+		header = append(header, "Key: "+sheet.Key)
+	}
+	if sheet.Capo != 0 {
+		header = append(header, fmt.Sprintf("Capo: %d", sheet.Capo))
+	}
+	return header
+}
+
+// textLine returns the row of chords and the row of lyrics of a line,
+// aligned by runes. Trailing spaces are trimmed.
+func textLine(segments []models.ChordSegment) (chords, lyrics string) {
+	var c, l strings.Builder
+	for _, segment := range segments {
+		text := segment.Text
+		width := utf8.RuneCountInString(text)
+		if segment.Chord != "" {
+			// Leave at least a space between the chord and the next one.
+			chordWidth := utf8.RuneCountInString(segment.Chord) + 1
+			c.WriteString(segment.Chord)
+			c.WriteByte(' ')
+			if width < chordWidth {
+				text += strings.Repeat(" ", chordWidth-width)
+				width = chordWidth
+			}
+			c.WriteString(strings.Repeat(" ", width-chordWidth))
+		} else {
+			c.WriteString(strings.Repeat(" ", width))
+		}
+		l.WriteString(text)
+	}
+	return strings.TrimRight(c.String(), " "), strings.TrimRight(l.String(), " ")
+}
+
+func sectionLabel(section models.ChordSection) string {
+	if section.Label != "" {
+		return section.Label
+	}
+	if section.Type == "" {
+		return ""
+	}
+	label := strings.ReplaceAll(section.Type, "-", " ")
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+var htmlTemplate = template.Must(template.New("sheet").Funcs(template.FuncMap{
+	"label": sectionLabel,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body{font-family:sans-serif;line-height:1.4}
+.line{display:flex;flex-wrap:wrap;align-items:flex-end;margin:0}
+.segment{display:inline-flex;flex-direction:column;white-space:pre}
+.chord{font-weight:bold;color:#a33;padding-right:.3em;min-height:1.4em}
+.section{margin-bottom:1em}
+.label{font-weight:bold}
+.comment{font-style:italic;color:#666}
+.tab{font-family:monospace;white-space:pre;margin:0}
+</style>
+</head>
+<body>
+{{- with .Title}}
+<h1>{{.}}</h1>
+{{- end}}
+{{- with .Subtitle}}
+<h2>{{.}}</h2>
+{{- end}}
+{{- with .Artist}}
+<p class="artist">{{.}}</p>
+{{- end}}
+{{- if or .Key .Capo}}
+<p class="meta">{{with .Key}}Key: {{.}}{{end}}{{if and .Key .Capo}} · {{end}}{{with .Capo}}Capo: {{.}}{{end}}</p>
+{{- end}}
+{{- range .Sections}}
+<div class="section{{with .Type}} {{.}}{{end}}">
+{{- with label .}}
+<p class="label">{{.}}</p>
+{{- end}}
+{{- range .Lines}}
+{{- if .Comment}}
+<p class="comment">{{.Comment}}</p>
+{{- else if .Segments}}
+<p class="line">{{range .Segments}}<span class="segment"><span class="chord">{{.Chord}}</span><span class="lyrics">{{.Text}}</span></span>{{end}}</p>
+{{- else}}
+<pre class="tab">{{.Tab}}</pre>
+{{- end}}
+{{- end}}
+</div>
+{{- end}}
+</body>
+</html>
+`))
+
+// HTML renders the sheet as an HTML document with chords above the lyrics.
+func HTML(sheet *models.ChordSheet) (string, error) {
+	var b bytes.Buffer
+	if err := htmlTemplate.Execute(&b, sheet); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Encode writes the sheet back as a ChordPro document. Comments starting
+// with # and unknown directives of the source are lost.
+func Encode(sheet *models.ChordSheet) string {
+	var b strings.Builder
+	for _, d := range []struct{ name, value string }{
+		{"title", sheet.Title},
+		{"subtitle", sheet.Subtitle},
+		{"artist", sheet.Artist},
+		{"key", sheet.Key},
+	} {
+		if d.value != "" {
+			fmt.Fprintf(&b, "{%s: %s}\n", d.name, d.value)
+		}
+	}
+	if sheet.Capo != 0 {
+		fmt.Fprintf(&b, "{capo: %d}\n", sheet.Capo)
+	}
+	for i, section := range sheet.Sections {
+		if i > 0 || b.Len() > 0 {
+			b.WriteByte('\n')
+		}
+		if section.Type != "" {
+			b.WriteString("{start_of_" + section.Type)
+			if section.Label != "" {
+				b.WriteString(": " + section.Label)
+			}
+			b.WriteString("}\n")
+		}
+		for _, line := range section.Lines {
+			switch {
+			case line.Comment != "":
+				fmt.Fprintf(&b, "{comment: %s}\n", line.Comment)
+			case len(line.Segments) == 0:
+				b.WriteString(line.Tab)
+				b.WriteByte('\n')
+			default:
+				for _, segment := range line.Segments {
+					if segment.Chord != "" {
+						b.WriteString("[" + segment.Chord + "]")
+					}
+					b.WriteString(segment.Text)
+				}
+				b.WriteByte('\n')
+			}
+		}
+		if section.Type != "" {
+			b.WriteString("{end_of_" + section.Type + "}\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,129 @@
+package chordpro
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// chordPattern matches a chord like "C", "F#m7", "Bbmaj7", "C6/9" or "D/F#":
+// the root, its accidental, the quality and the optional bass note.
+var chordPattern = regexp.MustCompile(`^([A-G])([#b]?)(.*?)(?:/([A-G])([#b]?))?$`)
+
+// qualityPattern matches the quality of a chord, so that words like "Coda"
+// are not taken for chords.
+var qualityPattern = regexp.MustCompile(`^(?:maj|min|dim|aug|sus|add|alt|no|m|M|[0-9]|[#b+\-()°ø^Δ/])*$`)
+
+var (
+	sharpNames = []string{"C", "C#", "D", "D#", "E", "F", "F#", "G", "G#", "A", "A#", "B"}
+	flatNames  = []string{"C", "Db", "D", "Eb", "E", "F", "Gb", "G", "Ab", "A", "Bb", "B"}
+	naturals   = map[byte]int{'C': 0, 'D': 2, 'E': 4, 'F': 5, 'G': 7, 'A': 9, 'B': 11}
+
+	// Keys written with flats, by the number of semitones above C.
+	flatMajorKeys = map[int]bool{5: true, 10: true, 3: true, 8: true, 1: true}
+	flatMinorKeys = map[int]bool{2: true, 7: true, 0: true, 5: true, 10: true, 3: true}
+)
+
+// Transpose returns a copy of the sheet with every chord and the key moved
+// by the given number of semitones. If the key is known, the accidentals of
+// the new key are used; otherwise chords keep their accidentals, and natural
+// notes become sharps when moving up and flats when moving down. Text in
+// brackets that isn't a chord, like "N.C.", is kept.
+func Transpose(sheet *models.ChordSheet, semitones int) *models.ChordSheet {
+	res := *sheet
+	res.Transpose = sheet.Transpose + semitones
+	if semitones%12 == 0 {
+		return &res
+	}
+
+	// spelling is nil if chords keep their own accidentals.
+	var spelling []string
+	if root, minor, ok := parseKey(sheet.Key); ok {
+		key := mod12(root + semitones)
+		if (minor && flatMinorKeys[key]) || (!minor && flatMajorKeys[key]) {
+			spelling = flatNames
+		} else {
+			spelling = sharpNames
+		}
+		res.Key = TransposeChord(sheet.Key, semitones, spelling)
+	}
+
+	res.Sections = make([]models.ChordSection, len(sheet.Sections))
+	for i, section := range sheet.Sections {
+		lines := make([]models.ChordLine, len(section.Lines))
+		for j, line := range section.Lines {
+			lines[j] = line
+			if len(line.Segments) == 0 {
+				continue
+			}
+			lines[j].Segments = make([]models.ChordSegment, len(line.Segments))
+			for k, segment := range line.Segments {
+				segment.Chord = TransposeChord(segment.Chord, semitones, spelling)
+				lines[j].Segments[k] = segment
+			}
+		}
+		section.Lines = lines
+		res.Sections[i] = section
+	}
+	return &res
+}
+
+// TransposeChord moves the chord by the given number of semitones, writing
+// its notes with the spelling, or with their own accidentals if spelling is
+// nil. Text that isn't a chord is returned unchanged.
+func TransposeChord(chord string, semitones int, spelling []string) string {
+	m := chordPattern.FindStringSubmatch(chord)
+	if m == nil || !qualityPattern.MatchString(m[3]) {
+		return chord
+	}
+	var b strings.Builder
+	b.WriteString(transposeNote(m[1], m[2], semitones, spelling))
+	b.WriteString(m[3])
+	if m[4] != "" {
+		b.WriteByte('/')
+		b.WriteString(transposeNote(m[4], m[5], semitones, spelling))
+	}
+	return b.String()
+}
+
+func transposeNote(natural, accidental string, semitones int, spelling []string) string {
+	note := mod12(semitone(natural, accidental) + semitones)
+	if spelling != nil {
+		return spelling[note]
+	}
+	switch {
+	case accidental == "b":
+		return flatNames[note]
+	case accidental == "#" || semitones > 0:
+		return sharpNames[note]
+	default:
+		return flatNames[note]
+	}
+}
+
+// parseKey returns the root of a key like "G", "F#m" or "Bb" and whether it
+// is minor.
+func parseKey(key string) (root int, minor bool, ok bool) {
+	m := chordPattern.FindStringSubmatch(strings.TrimSpace(key))
+	if m == nil || !qualityPattern.MatchString(m[3]) {
+		return 0, false, false
+	}
+	minor = strings.HasPrefix(m[3], "m") && !strings.HasPrefix(m[3], "maj")
+	return semitone(m[1], m[2]), minor, true
+}
+
+func semitone(natural, accidental string) int {
+	n := naturals[natural[0]]
+	switch accidental {
+	case "#":
+		n++
+	case "b":
+		n--
+	}
+	return mod12(n)
+}
+
+func mod12(n int) int {
+	return (n%12 + 12) % 12
+}
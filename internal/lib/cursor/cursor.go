@@ -0,0 +1,38 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// Encode turns the cursor into an opaque token that is safe to use in URLs.
+func Encode(c *models.Cursor) string {
+	data, err := json.Marshal(c)
+	if err != nil {
+		// models.Cursor only contains plain values, so marshalling can't fail.
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Decode parses a token produced by Encode.
+func Decode(token string) (*models.Cursor, error) {
+	const op = "cursor.Decode"
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidCursor)
+	}
+	var c models.Cursor
+	if err = json.Unmarshal(data, &c); err != nil || c.ID <= 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidCursor)
+	}
+	return &c, nil
+}
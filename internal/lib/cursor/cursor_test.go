@@ -0,0 +1,57 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+func TestRoundTrip(t *testing.T) {
+	cursors := []*models.Cursor{
+		{ID: 1},
+		{ID: 42, Sort: "-release_date,title", Values: []string{"2006-06-19", "Supermassive Black Hole"}},
+		{ID: 7, Sort: "group", Values: []string{"Мумий Тролль & Co / \"?\""}},
+	}
+	for _, c := range cursors {
+		token := Encode(c)
+		got, err := Decode(token)
+		if err != nil {
+			t.Fatalf("Decode(%q): unexpected error: %v", token, err)
+		}
+		if !reflect.DeepEqual(got, c) {
+			t.Errorf("Decode(Encode(%+v)) = %+v", c, got)
+		}
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	valid := Encode(&models.Cursor{ID: 42, Sort: "title", Values: []string{"Uprising"}})
+	encode := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "empty", token: ""},
+		{name: "not base64", token: "not a cursor!"},
+		{name: "padded base64", token: valid + "=="},
+		{name: "truncated", token: valid[:len(valid)-3]},
+		{name: "tampered", token: "X" + valid[1:]},
+		{name: "not JSON", token: encode("id=42")},
+		{name: "wrong types", token: encode(`{"id": "42"}`)},
+		{name: "missing id", token: encode(`{"sort": "title"}`)},
+		{name: "negative id", token: encode(`{"id": -1}`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decode(tt.token); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("error = %v, want %v", err, ErrInvalidCursor)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package langtag
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+var (
+	ErrInvalid = errors.New("must be a BCP 47 language tag like en or pt-BR")
+)
+
+// Canonical returns the canonical form of a BCP 47 language tag, like
+// "en-US" for "en_us", so that tags can be compared as strings.
+func Canonical(tag string) (string, error) {
+	parsed, err := language.Parse(strings.TrimSpace(tag))
+	if err != nil || parsed == language.Und {
+		return "", ErrInvalid
+	}
+	return parsed.String(), nil
+}
+
+// preference is a language range of Accept-Language with its weight.
+type preference struct {
+	tag     string
+	quality float64
+}
+
+// Match picks the tag of the available ones that an Accept-Language header
+// prefers, as the lookup of RFC 4647: every range, from the most preferred
+// one, matches the same tag, then its more specific variants, like "en-GB"
+// for "en", then tags it is truncated to, like "zh-Hant" and "zh" for
+// "zh-Hant-TW". Ranges with q=0, "*" and malformed ones are skipped, and
+// ok is false if no tag is acceptable. Available tags must be canonical.
+func Match(acceptLanguage string, available []string) (tag string, ok bool) {
+	for _, p := range parseAcceptLanguage(acceptLanguage) {
+		if tag, ok = lookup(p.tag, available); ok {
+			return tag, true
+		}
+	}
+	return "", false
+}
+
+func lookup(want string, available []string) (string, bool) {
+	for _, tag := range available {
+		if strings.EqualFold(tag, want) {
+			return tag, true
+		}
+	}
+	for _, tag := range available {
+		if len(tag) > len(want) && strings.EqualFold(tag[:len(want)], want) && tag[len(want)] == '-' {
+			return tag, true
+		}
+	}
+	for i := strings.LastIndexByte(want, '-'); i > 0; i = strings.LastIndexByte(want, '-') {
+		want = want[:i]
+		for _, tag := range available {
+			if strings.EqualFold(tag, want) {
+				return tag, true
+			}
+		}
+	}
+	return "", false
+}
+
+// parseAcceptLanguage returns the acceptable language ranges of the header
+// in canonical form, from the most preferred one. Ranges of equal weight
+// keep their order.
+func parseAcceptLanguage(header string) []preference {
+	var res []preference
+	for _, item := range strings.Split(header, ",") {
+		rng, params, _ := strings.Cut(item, ";")
+		rng = strings.TrimSpace(rng)
+		if rng == "" || rng == "*" {
+			continue
+		}
+		quality := 1.0
+		if name, value, found := strings.Cut(params, "="); found && strings.TrimSpace(name) == "q" {
+			q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || q < 0 || q > 1 {
+				continue
+			}
+			quality = q
+		}
+		tag, err := Canonical(rng)
+		if err != nil || quality == 0 {
+			continue
+		}
+		res = append(res, preference{tag: tag, quality: quality})
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].quality > res[j].quality })
+	return res
+}
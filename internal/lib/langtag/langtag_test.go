@@ -0,0 +1,78 @@
+package langtag
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		tag     string
+		want    string
+		wantErr bool
+	}{
+		{tag: "en", want: "en"},
+		{tag: "en_us", want: "en-US"},
+		{tag: " PT-br ", want: "pt-BR"},
+		{tag: "zh-hant-tw", want: "zh-Hant-TW"},
+		{tag: "", wantErr: true},
+		{tag: "und", wantErr: true},
+		{tag: "english", wantErr: true},
+		{tag: "en-", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, err := Canonical(tt.tag)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalid) {
+					t.Fatalf("error = %v, want %v", err, ErrInvalid)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Canonical(%q) = %q, want %q", tt.tag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    string
+		available []string
+		want      string
+		wantOK    bool
+	}{
+		{name: "exact", header: "de", available: []string{"en", "de"}, want: "de", wantOK: true},
+		{name: "first range wins", header: "de, en", available: []string{"en", "de"}, want: "de", wantOK: true},
+		{name: "q ordering", header: "de;q=0.5, en;q=0.8", available: []string{"en", "de"}, want: "en", wantOK: true},
+		{name: "equal q keeps order", header: "de;q=0.5, en;q=0.5", available: []string{"en", "de"}, want: "de", wantOK: true},
+		{name: "default q is 1", header: "de;q=0.9, en", available: []string{"de", "en"}, want: "en", wantOK: true},
+		{name: "more specific tag", header: "en", available: []string{"de", "en-GB"}, want: "en-GB", wantOK: true},
+		{name: "exact before more specific", header: "en", available: []string{"en-GB", "en"}, want: "en", wantOK: true},
+		{name: "prefix only at subtag boundary", header: "e", available: []string{"en"}},
+		{name: "truncation", header: "zh-Hant-TW", available: []string{"zh", "zh-Hant"}, want: "zh-Hant", wantOK: true},
+		{name: "truncation to the language", header: "ru-RU", available: []string{"en", "ru"}, want: "ru", wantOK: true},
+		{name: "case and form of ranges", header: "EN_gb", available: []string{"en-GB"}, want: "en-GB", wantOK: true},
+		{name: "more preferred range truncated", header: "ru-RU, en;q=0.8", available: []string{"en", "ru"}, want: "ru", wantOK: true},
+		{name: "q=0 is not acceptable", header: "de;q=0, en;q=0.1", available: []string{"de", "en"}, want: "en", wantOK: true},
+		{name: "only q=0", header: "de;q=0", available: []string{"de"}},
+		{name: "wildcard skipped", header: "*", available: []string{"de"}},
+		{name: "malformed ranges skipped", header: "??, de;q=x, de;q=2, en", available: []string{"de", "en"}, want: "en", wantOK: true},
+		{name: "no match", header: "fr, it", available: []string{"de", "en"}},
+		{name: "empty header", header: "", available: []string{"de"}},
+		{name: "nothing available", header: "de", available: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Match(tt.header, tt.available)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Match(%q, %q) = %q, %v, want %q, %v", tt.header, tt.available, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
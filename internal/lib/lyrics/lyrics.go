@@ -0,0 +1,204 @@
+package lyrics
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// sectionTypes maps the keywords of section labels, lowercased and with
+// spaces and dashes removed, to section types.
+var sectionTypes = map[string]string{
+	"intro":        models.SectionIntro,
+	"интро":        models.SectionIntro,
+	"вступление":   models.SectionIntro,
+	"verse":        models.SectionVerse,
+	"куплет":       models.SectionVerse,
+	"prechorus":    models.SectionPreChorus,
+	"предприпев":   models.SectionPreChorus,
+	"chorus":       models.SectionChorus,
+	"refrain":      models.SectionChorus,
+	"hook":         models.SectionChorus,
+	"припев":       models.SectionChorus,
+	"bridge":       models.SectionBridge,
+	"бридж":        models.SectionBridge,
+	"outro":        models.SectionOutro,
+	"аутро":        models.SectionOutro,
+	"концовка":     models.SectionOutro,
+	"заключение":   models.SectionOutro,
+	"instrumental": models.SectionOther,
+}
+
+// block is a group of consecutive lines of the text.
+type block struct {
+	typ       string // Empty if the block has no label.
+	label     string
+	firstLine int
+	lines     []string
+}
+
+// Parse splits lyrics into sections. Sections are separated by blank lines
+// or labels such as "[Chorus]", "[Verse 2: Artist]", "Chorus:" or "(Bridge)".
+// Unlabelled sections repeating a labelled one take its type, other
+// unlabelled sections that occur more than once are choruses, and the rest
+// are verses. Lines are numbered from 1 across the whole song, skipping blank
+// lines and labels.
+func Parse(text string) []models.Section {
+	blocks := split(text)
+
+	// Types of labelled sections and the number of occurrences of each
+	// section, by their normalised lines.
+	labelled := make(map[string]string)
+	occurrences := make(map[string]int)
+	for _, b := range blocks {
+		key := normalise(b.lines)
+		occurrences[key]++
+		if b.typ != "" {
+			if _, ok := labelled[key]; !ok {
+				labelled[key] = b.typ
+			}
+		}
+	}
+
+	sections := make([]models.Section, 0, len(blocks))
+	counts := make(map[string]int)
+	for _, b := range blocks {
+		typ := b.typ
+		if typ == "" {
+			key := normalise(b.lines)
+			switch t, ok := labelled[key]; {
+			case ok:
+				typ = t
+			case occurrences[key] > 1:
+				typ = models.SectionChorus
+			default:
+				typ = models.SectionVerse
+			}
+		}
+		counts[typ]++
+		sections = append(sections, models.Section{
+			Type:      typ,
+			Index:     counts[typ],
+			Label:     b.label,
+			FirstLine: b.firstLine,
+			Lines:     b.lines,
+		})
+	}
+	return sections
+}
+
+// split groups the lines of the text into blocks. A label without lines is
+// attached to the following lines, even across blank lines.
+func split(text string) []block {
+	var (
+		blocks  []block
+		current block
+		lineNo  int
+	)
+	flush := func() {
+		if len(current.lines) > 0 {
+			blocks = append(blocks, current)
+			current = block{}
+		}
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			flush()
+			continue
+		}
+		if typ, label, ok := parseLabel(line); ok {
+			flush()
+			current = block{typ: typ, label: label}
+			continue
+		}
+		lineNo++
+		if len(current.lines) == 0 {
+			current.firstLine = lineNo
+		}
+		current.lines = append(current.lines, line)
+	}
+	flush()
+	return blocks
+}
+
+// parseLabel recognises a line labelling a section. Any text in square
+// brackets is a label, while parenthesised, colon-terminated and bare labels
+// must name a known section type, so that lyrics like "(ooh)" are kept.
+func parseLabel(line string) (typ, label string, ok bool) {
+	switch {
+	case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+		label = strings.TrimSpace(line[1 : len(line)-1])
+		if label == "" {
+			return "", "", false
+		}
+		// The label may name the performer, e.g. "Verse 1: Artist".
+		head, _, _ := strings.Cut(label, ":")
+		if typ, ok = sectionType(head); !ok {
+			typ = models.SectionOther
+		}
+		return typ, label, true
+	case strings.HasPrefix(line, "(") && strings.HasSuffix(line, ")"):
+		label = strings.TrimSpace(line[1 : len(line)-1])
+	case strings.HasSuffix(line, ":"):
+		label = strings.TrimSpace(strings.TrimSuffix(line, ":"))
+	default:
+		label = line
+	}
+	if typ, ok = sectionType(label); !ok {
+		return "", "", false
+	}
+	return typ, label, true
+}
+
+// sectionType returns the type named by a label like "Chorus", "Verse 2",
+// "Pre-Chorus" or "Припев x2". Numbers and repetition marks are ignored.
+func sectionType(label string) (string, bool) {
+	var key strings.Builder
+	for _, word := range strings.Fields(strings.ToLower(label)) {
+		word = strings.Trim(word, ".#")
+		if isNumber(word) || isRepetition(word) {
+			continue
+		}
+		for _, r := range word {
+			if unicode.IsLetter(r) {
+				key.WriteRune(r)
+			} else if r != '-' && r != '_' {
+				// Anything else means the line is not a label.
+				return "", false
+			}
+		}
+	}
+	typ, ok := sectionTypes[key.String()]
+	return typ, ok
+}
+
+func isNumber(word string) bool {
+	return word != "" && strings.Trim(word, "0123456789") == ""
+}
+
+// isRepetition reports whether the word is a mark like "x2" or "2x".
+func isRepetition(word string) bool {
+	for _, mark := range []string{"x", "х", "×"} {
+		if s, ok := strings.CutPrefix(word, mark); ok && isNumber(s) {
+			return true
+		}
+		if s, ok := strings.CutSuffix(word, mark); ok && isNumber(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalise returns the lines in a form that ignores case, spacing and
+// trailing punctuation, to find repeated sections.
+func normalise(lines []string) string {
+	res := make([]string, len(lines))
+	for i, line := range lines {
+		line = strings.Join(strings.Fields(strings.ToLower(line)), " ")
+		res[i] = strings.TrimRight(line, ",.!?;:…")
+	}
+	return strings.Join(res, "\n")
+}
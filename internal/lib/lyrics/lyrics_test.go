@@ -0,0 +1,91 @@
+package lyrics
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []models.Section
+	}{
+		{
+			name: "empty",
+			text: "",
+			want: []models.Section{},
+		},
+		{
+			name: "unlabelled verses",
+			text: "One\nTwo\n\nThree",
+			want: []models.Section{
+				{Type: models.SectionVerse, Index: 1, FirstLine: 1, Lines: []string{"One", "Two"}},
+				{Type: models.SectionVerse, Index: 2, FirstLine: 3, Lines: []string{"Three"}},
+			},
+		},
+		{
+			name: "repeated section is a chorus",
+			text: "One\n\nOoh alight\n\nTwo\n\nooh  alight!",
+			want: []models.Section{
+				{Type: models.SectionVerse, Index: 1, FirstLine: 1, Lines: []string{"One"}},
+				{Type: models.SectionChorus, Index: 1, FirstLine: 2, Lines: []string{"Ooh alight"}},
+				{Type: models.SectionVerse, Index: 2, FirstLine: 3, Lines: []string{"Two"}},
+				{Type: models.SectionChorus, Index: 2, FirstLine: 4, Lines: []string{"ooh  alight!"}},
+			},
+		},
+		{
+			name: "labels in brackets split sections",
+			text: "[Intro]\nHey\n[Verse 1: Muse]\nOne\n[Pre-Chorus]\nWait\n[Solo]\nLa",
+			want: []models.Section{
+				{Type: models.SectionIntro, Index: 1, Label: "Intro", FirstLine: 1, Lines: []string{"Hey"}},
+				{Type: models.SectionVerse, Index: 1, Label: "Verse 1: Muse", FirstLine: 2, Lines: []string{"One"}},
+				{Type: models.SectionPreChorus, Index: 1, Label: "Pre-Chorus", FirstLine: 3, Lines: []string{"Wait"}},
+				{Type: models.SectionOther, Index: 1, Label: "Solo", FirstLine: 4, Lines: []string{"La"}},
+			},
+		},
+		{
+			name: "colon, parenthesised and bare labels",
+			text: "Chorus:\nOoh\n\n(Bridge)\nLa\n\nПрипев x2\nО-о\n\nOutro\nBye",
+			want: []models.Section{
+				{Type: models.SectionChorus, Index: 1, Label: "Chorus", FirstLine: 1, Lines: []string{"Ooh"}},
+				{Type: models.SectionBridge, Index: 1, Label: "Bridge", FirstLine: 2, Lines: []string{"La"}},
+				{Type: models.SectionChorus, Index: 2, Label: "Припев x2", FirstLine: 3, Lines: []string{"О-о"}},
+				{Type: models.SectionOutro, Index: 1, Label: "Outro", FirstLine: 4, Lines: []string{"Bye"}},
+			},
+		},
+		{
+			name: "lyrics that look like labels are kept",
+			text: "(ooh)\nI said:\n\nVerse of mine",
+			want: []models.Section{
+				{Type: models.SectionVerse, Index: 1, FirstLine: 1, Lines: []string{"(ooh)", "I said:"}},
+				{Type: models.SectionVerse, Index: 2, FirstLine: 3, Lines: []string{"Verse of mine"}},
+			},
+		},
+		{
+			name: "label attached across blank lines",
+			text: "[Chorus]\n\nOoh\n\nOne\n\nOoh",
+			want: []models.Section{
+				{Type: models.SectionChorus, Index: 1, Label: "Chorus", FirstLine: 1, Lines: []string{"Ooh"}},
+				{Type: models.SectionVerse, Index: 1, FirstLine: 2, Lines: []string{"One"}},
+				{Type: models.SectionChorus, Index: 2, FirstLine: 3, Lines: []string{"Ooh"}},
+			},
+		},
+		{
+			name: "empty brackets are lyrics",
+			text: "[]\nOne",
+			want: []models.Section{
+				{Type: models.SectionVerse, Index: 1, FirstLine: 1, Lines: []string{"[]", "One"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Parse(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
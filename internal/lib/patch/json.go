@@ -0,0 +1,284 @@
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Operation is a single operation of an RFC 6902 JSON Patch.
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// JSONPatch applies an RFC 6902 JSON Patch to the JSON document. The
+// operations are applied in order and the patch fails as a whole if any of
+// them fails.
+func JSONPatch(doc, patch []byte) ([]byte, error) {
+	const op = "patch.JSONPatch"
+
+	var target any
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	var ops []Operation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrInvalidPatch, err)
+	}
+
+	var err error
+	for i, o := range ops {
+		target, err = apply(target, o)
+		if err != nil {
+			return nil, fmt.Errorf("%s: operation %d (%s %s): %w", op, i, o.Op, o.Path, err)
+		}
+	}
+
+	res, err := json.Marshal(target)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return res, nil
+}
+
+// apply runs one operation against the document and returns the new document.
+func apply(doc any, o Operation) (any, error) {
+	path, err := parsePointer(o.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch o.Op {
+	case "add", "replace", "test":
+		if len(o.Value) == 0 {
+			return nil, fmt.Errorf("%w: missing value", ErrInvalidPatch)
+		}
+		var value any
+		if err = json.Unmarshal(o.Value, &value); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+		}
+		switch o.Op {
+		case "add":
+			return add(doc, path, value)
+		case "replace":
+			if doc, _, err = remove(doc, path); err != nil {
+				return nil, err
+			}
+			return add(doc, path, value)
+		default:
+			current, err := get(doc, path)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, ErrTestFailed
+			}
+			return doc, nil
+		}
+	case "remove":
+		doc, _, err = remove(doc, path)
+		return doc, err
+	case "move", "copy":
+		from, err := parsePointer(o.From)
+		if err != nil {
+			return nil, err
+		}
+		var value any
+		if o.Op == "move" {
+			if isPrefix(from, path) && len(from) < len(path) {
+				return nil, fmt.Errorf("%w: can't move a value into itself", ErrInvalidPatch)
+			}
+			doc, value, err = remove(doc, from)
+		} else {
+			value, err = get(doc, from)
+			value = deepCopy(value)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	default:
+		return nil, fmt.Errorf("%w: unknown operation %q", ErrInvalidPatch, o.Op)
+	}
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into unescaped reference tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: invalid path %q", ErrInvalidPatch, pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+// get returns the value the path points to.
+func get(doc any, path []string) (any, error) {
+	for _, token := range path {
+		switch node := doc.(type) {
+		case map[string]any:
+			value, ok := node[token]
+			if !ok {
+				return nil, ErrPathNotFound
+			}
+			doc = value
+		case []any:
+			i, err := arrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[i]
+		default:
+			return nil, ErrPathNotFound
+		}
+	}
+	return doc, nil
+}
+
+// add inserts the value at the path. Values in arrays are shifted to make
+// room, and "-" appends to an array.
+func add(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+
+	switch node := parent.(type) {
+	case map[string]any:
+		node[last] = value
+		return doc, nil
+	case []any:
+		i := len(node)
+		if last != "-" {
+			if i, err = arrayIndex(last, len(node)); err != nil {
+				return nil, err
+			}
+		}
+		node = append(node, nil)
+		copy(node[i+1:], node[i:])
+		node[i] = value
+		return set(doc, path[:len(path)-1], node)
+	default:
+		return nil, ErrPathNotFound
+	}
+}
+
+// remove deletes the value at the path and returns it.
+func remove(doc any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, doc, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	last := path[len(path)-1]
+
+	switch node := parent.(type) {
+	case map[string]any:
+		value, ok := node[last]
+		if !ok {
+			return nil, nil, ErrPathNotFound
+		}
+		delete(node, last)
+		return doc, value, nil
+	case []any:
+		i, err := arrayIndex(last, len(node)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		value := node[i]
+		node = append(node[:i], node[i+1:]...)
+		doc, err = set(doc, path[:len(path)-1], node)
+		return doc, value, err
+	default:
+		return nil, nil, ErrPathNotFound
+	}
+}
+
+// set replaces the value at an existing path. It is needed for arrays, whose
+// slice header changes when elements are added or removed.
+func set(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+
+	switch node := parent.(type) {
+	case map[string]any:
+		node[last] = value
+	case []any:
+		i, err := arrayIndex(last, len(node)-1)
+		if err != nil {
+			return nil, err
+		}
+		node[i] = value
+	default:
+		return nil, ErrPathNotFound
+	}
+	return doc, nil
+}
+
+// arrayIndex parses an array index token that must not exceed limit.
+func arrayIndex(token string, limit int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, ErrPathNotFound
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > limit {
+		return 0, ErrPathNotFound
+	}
+	return i, nil
+}
+
+// isPrefix reports whether prefix is a prefix of path.
+func isPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// deepCopy copies a decoded JSON value so that copies don't share maps or
+// slices with the original.
+func deepCopy(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		res := make(map[string]any, len(v))
+		for key, item := range v {
+			res[key] = deepCopy(item)
+		}
+		return res
+	case []any:
+		res := make([]any, len(v))
+		for i, item := range v {
+			res[i] = deepCopy(item)
+		}
+		return res
+	default:
+		return v
+	}
+}
@@ -0,0 +1,54 @@
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidPatch = errors.New("invalid patch")
+	ErrTestFailed   = errors.New("patch test failed")
+	ErrPathNotFound = errors.New("patch path not found")
+)
+
+// MergePatch applies an RFC 7396 JSON Merge Patch to the JSON document.
+// Members of the patch replace members of the document, null removes them,
+// and nested objects are merged recursively.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	const op = "patch.MergePatch"
+
+	var target, p any
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrInvalidPatch, err)
+	}
+
+	res, err := json.Marshal(merge(target, p))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return res, nil
+}
+
+// merge implements the MergePatch algorithm from section 2 of RFC 7396.
+func merge(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = map[string]any{}
+	}
+	for key, value := range p {
+		if value == nil {
+			delete(t, key)
+			continue
+		}
+		t[key] = merge(t[key], value)
+	}
+	return t
+}
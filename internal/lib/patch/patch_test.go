@@ -0,0 +1,161 @@
+package patch
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestJSONPatch(t *testing.T) {
+	const doc = `{"title":"Uprising","tags":["rock","alt"],"info":{"year":2009}}`
+
+	tests := []struct {
+		name    string
+		patch   string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "add member",
+			patch: `[{"op":"add","path":"/link","value":"https://example.com"}]`,
+			want:  `{"info":{"year":2009},"link":"https://example.com","tags":["rock","alt"],"title":"Uprising"}`,
+		},
+		{
+			name:  "add into array",
+			patch: `[{"op":"add","path":"/tags/1","value":"muse"}]`,
+			want:  `{"info":{"year":2009},"tags":["rock","muse","alt"],"title":"Uprising"}`,
+		},
+		{
+			name:  "append to array",
+			patch: `[{"op":"add","path":"/tags/-","value":"muse"}]`,
+			want:  `{"info":{"year":2009},"tags":["rock","alt","muse"],"title":"Uprising"}`,
+		},
+		{
+			name:  "remove array element",
+			patch: `[{"op":"remove","path":"/tags/0"}]`,
+			want:  `{"info":{"year":2009},"tags":["alt"],"title":"Uprising"}`,
+		},
+		{
+			name:  "replace nested member",
+			patch: `[{"op":"replace","path":"/info/year","value":2010}]`,
+			want:  `{"info":{"year":2010},"tags":["rock","alt"],"title":"Uprising"}`,
+		},
+		{
+			name:  "move",
+			patch: `[{"op":"move","from":"/info/year","path":"/year"}]`,
+			want:  `{"info":{},"tags":["rock","alt"],"title":"Uprising","year":2009}`,
+		},
+		{
+			name:  "copy is independent",
+			patch: `[{"op":"copy","from":"/info","path":"/meta"},{"op":"replace","path":"/meta/year","value":1}]`,
+			want:  `{"info":{"year":2009},"meta":{"year":1},"tags":["rock","alt"],"title":"Uprising"}`,
+		},
+		{
+			name:  "escaped pointer",
+			patch: `[{"op":"add","path":"/a~1b~0c","value":1}]`,
+			want:  `{"a/b~c":1,"info":{"year":2009},"tags":["rock","alt"],"title":"Uprising"}`,
+		},
+		{
+			name:  "test passes",
+			patch: `[{"op":"test","path":"/title","value":"Uprising"},{"op":"remove","path":"/info"}]`,
+			want:  `{"tags":["rock","alt"],"title":"Uprising"}`,
+		},
+		{
+			name:    "test fails",
+			patch:   `[{"op":"test","path":"/title","value":"Hysteria"}]`,
+			wantErr: ErrTestFailed,
+		},
+		{
+			name:    "missing path",
+			patch:   `[{"op":"remove","path":"/link"}]`,
+			wantErr: ErrPathNotFound,
+		},
+		{
+			name:    "index out of range",
+			patch:   `[{"op":"add","path":"/tags/3","value":"x"}]`,
+			wantErr: ErrPathNotFound,
+		},
+		{
+			name:    "leading zero index",
+			patch:   `[{"op":"remove","path":"/tags/01"}]`,
+			wantErr: ErrPathNotFound,
+		},
+		{
+			name:    "move into itself",
+			patch:   `[{"op":"move","from":"/info","path":"/info/nested"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "unknown operation",
+			patch:   `[{"op":"rename","path":"/title"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "missing value",
+			patch:   `[{"op":"add","path":"/title"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "invalid pointer",
+			patch:   `[{"op":"remove","path":"title"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "not a list of operations",
+			patch:   `{"op":"remove","path":"/title"}`,
+			wantErr: ErrInvalidPatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := JSONPatch([]byte(doc), []byte(tt.patch))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		patch   string
+		want    string
+		wantErr error
+	}{
+		{name: "replace member", doc: `{"a":"b"}`, patch: `{"a":"c"}`, want: `{"a":"c"}`},
+		{name: "add member", doc: `{"a":"b"}`, patch: `{"b":"c"}`, want: `{"a":"b","b":"c"}`},
+		{name: "null removes", doc: `{"a":"b","b":"c"}`, patch: `{"a":null}`, want: `{"b":"c"}`},
+		{name: "arrays are replaced", doc: `{"a":[1,2]}`, patch: `{"a":[3]}`, want: `{"a":[3]}`},
+		{name: "nested merge", doc: `{"a":{"b":1,"c":2}}`, patch: `{"a":{"c":null,"d":3}}`, want: `{"a":{"b":1,"d":3}}`},
+		{name: "object over scalar", doc: `{"a":"b"}`, patch: `{"a":{"c":null,"d":1}}`, want: `{"a":{"d":1}}`},
+		{name: "non-object patch", doc: `{"a":"b"}`, patch: `["c"]`, want: `["c"]`},
+		{name: "invalid patch", doc: `{}`, patch: `{`, wantErr: ErrInvalidPatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergePatch([]byte(tt.doc), []byte(tt.patch))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,347 @@
+package synced
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+var (
+	ErrInvalid = errors.New("invalid synced lyrics")
+)
+
+// Formats of synced lyrics.
+const (
+	FormatJSON = "json"
+	FormatLRC  = "lrc"
+	FormatVTT  = "vtt"
+)
+
+// lastCueDuration is how long the last line is shown in WebVTT if its end is
+// unknown.
+const lastCueDuration = 5 * 1000
+
+// Validate checks that the lines are not empty, start at non-negative
+// times that strictly increase, and end after they start but not after the
+// next line starts.
+func Validate(lines []models.SyncedLine) error {
+	if len(lines) == 0 {
+		return fmt.Errorf("%w: no lines", ErrInvalid)
+	}
+	for i, line := range lines {
+		switch {
+		case line.Start < 0 || math.IsNaN(line.Start) || math.IsInf(line.Start, 0):
+			return fmt.Errorf("%w: line %d: start must be a non-negative number of seconds", ErrInvalid, i+1)
+		case i > 0 && line.Start <= lines[i-1].Start:
+			return fmt.Errorf("%w: line %d: start %s is not after the start of the previous line",
+				ErrInvalid, i+1, formatLRCTime(millis(line.Start)))
+		case line.End != 0 && line.End <= line.Start:
+			return fmt.Errorf("%w: line %d: end must be after start", ErrInvalid, i+1)
+		case line.End != 0 && i+1 < len(lines) && line.End > lines[i+1].Start:
+			return fmt.Errorf("%w: line %d: end is after the start of the next line", ErrInvalid, i+1)
+		}
+	}
+	return nil
+}
+
+// At returns the line shown at the playback position t in seconds and the
+// next line with text. Current is nil before the first line, after a line
+// has ended, and during empty lines that mark pauses.
+func At(lines []models.SyncedLine, t float64) (current, next *models.SyncedLine) {
+	// i is the first line starting after t.
+	i := sort.Search(len(lines), func(i int) bool { return lines[i].Start > t })
+	if i > 0 {
+		line := &lines[i-1]
+		if line.Text != "" && (line.End == 0 || t < line.End) {
+			current = line
+		}
+	}
+	for ; i < len(lines); i++ {
+		if lines[i].Text != "" {
+			next = &lines[i]
+			break
+		}
+	}
+	return current, next
+}
+
+// ParseLRC reads lyrics in the LRC format: one line per timestamp, like
+// "[01:23.45]text". A line with several timestamps, like
+// "[00:12.00][00:45.00]text", is repeated at every one of them, and the lines
+// are sorted by start. Metadata tags like "[ar:Artist]" are ignored, except
+// "[offset:+500]", which shifts the lines earlier by that many milliseconds;
+// lines shifted before the start of the song start at 0.
+func ParseLRC(data string) ([]models.SyncedLine, error) {
+	var (
+		lines  []models.SyncedLine
+		offset int64
+	)
+	// Files saved by some editors start with a byte order mark.
+	data = strings.TrimPrefix(data, "\ufeff")
+	for n, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		tag, text, ok := cutTag(line)
+		if !ok {
+			return nil, fmt.Errorf("%w: line %d: missing timestamp", ErrInvalid, n+1)
+		}
+		ms, err := parseLRCTime(tag)
+		if err != nil {
+			// Not a timestamp, so it should be a metadata tag.
+			key, value, isMeta := strings.Cut(tag, ":")
+			if !isMeta || text != "" {
+				return nil, fmt.Errorf("%w: line %d: invalid timestamp %q", ErrInvalid, n+1, tag)
+			}
+			if strings.EqualFold(strings.TrimSpace(key), "offset") {
+				if offset, err = strconv.ParseInt(strings.TrimSpace(value), 10, 64); err != nil {
+					return nil, fmt.Errorf("%w: line %d: invalid offset %q", ErrInvalid, n+1, value)
+				}
+			}
+			continue
+		}
+
+		// Collect the rest of the timestamps in front of the text.
+		starts := []int64{ms}
+		for {
+			tag, rest, ok := cutTag(strings.TrimSpace(text))
+			if !ok {
+				break
+			}
+			if ms, err = parseLRCTime(tag); err != nil {
+				break
+			}
+			starts = append(starts, ms)
+			text = rest
+		}
+		text = strings.TrimSpace(text)
+		for _, ms := range starts {
+			lines = append(lines, models.SyncedLine{Start: seconds(ms), Text: text})
+		}
+	}
+	// Repeated lines may come before the lines they surround, and LRC doesn't
+	// require lines to be in order, so sort them by start.
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].Start < lines[j].Start })
+	// The offset applies to every line, wherever the tag is. Of the lines it
+	// moves before the start of the song only the last one is kept, starting
+	// at 0, unless another line starts right at 0.
+	cut := 0
+	for cut < len(lines) && millis(lines[cut].Start)-offset < 0 {
+		cut++
+	}
+	for i := range lines {
+		lines[i].Start = seconds(max(millis(lines[i].Start)-offset, 0))
+	}
+	if cut > 0 && (cut == len(lines) || lines[cut].Start > 0) {
+		cut--
+	}
+	lines = lines[cut:]
+	return lines, Validate(lines)
+}
+
+// EncodeLRC writes the lines in the LRC format. The ends of lines followed
+// by a pause are written as empty lines, and lines of text spanning several
+// rows are joined with spaces.
+func EncodeLRC(lines []models.SyncedLine) string {
+	var b strings.Builder
+	for i, line := range lines {
+		text := strings.Join(strings.Fields(line.Text), " ")
+		fmt.Fprintf(&b, "[%s]%s\n", formatLRCTime(millis(line.Start)), text)
+		if line.End != 0 && (i+1 == len(lines) || line.End < lines[i+1].Start) {
+			fmt.Fprintf(&b, "[%s]\n", formatLRCTime(millis(line.End)))
+		}
+	}
+	return b.String()
+}
+
+// ParseVTT reads lyrics in the WebVTT format. Every cue becomes a line, and
+// the lines of a cue are joined with a newline. Cue settings, notes, styles
+// and regions are ignored.
+func ParseVTT(data string) ([]models.SyncedLine, error) {
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	// Files saved by some editors start with a byte order mark.
+	data = strings.TrimPrefix(data, "\ufeff")
+	blocks := strings.Split(strings.TrimSpace(data), "\n\n")
+	if !strings.HasPrefix(blocks[0], "WEBVTT") {
+		return nil, fmt.Errorf("%w: missing WEBVTT header", ErrInvalid)
+	}
+
+	var lines []models.SyncedLine
+	for _, block := range blocks[1:] {
+		block = strings.Trim(block, "\n")
+		if block == "" || strings.HasPrefix(block, "NOTE") || strings.HasPrefix(block, "STYLE") ||
+			strings.HasPrefix(block, "REGION") {
+			continue
+		}
+		cue := len(lines) + 1
+		rows := strings.Split(block, "\n")
+		// The timing may follow an identifier of the cue.
+		if !strings.Contains(rows[0], "-->") && len(rows) > 1 {
+			rows = rows[1:]
+		}
+		fields := strings.Fields(rows[0])
+		if len(fields) < 3 || fields[1] != "-->" {
+			return nil, fmt.Errorf("%w: cue %d: invalid timing %q", ErrInvalid, cue, rows[0])
+		}
+		start, err := parseVTTTime(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w: cue %d: invalid start %q", ErrInvalid, cue, fields[0])
+		}
+		end, err := parseVTTTime(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("%w: cue %d: invalid end %q", ErrInvalid, cue, fields[2])
+		}
+		lines = append(lines, models.SyncedLine{
+			Start: seconds(start),
+			End:   seconds(end),
+			Text:  strings.TrimSpace(strings.Join(rows[1:], "\n")),
+		})
+	}
+	return lines, Validate(lines)
+}
+
+// EncodeVTT writes the lines in the WebVTT format. Lines without an end last
+// until the next line, and empty lines are skipped.
+func EncodeVTT(lines []models.SyncedLine) string {
+	var b strings.Builder
+	b.WriteString("WEBVTT\n")
+	for i, line := range lines {
+		if line.Text == "" {
+			continue
+		}
+		start, end := millis(line.Start), millis(line.End)
+		if line.End == 0 {
+			if i+1 < len(lines) {
+				end = millis(lines[i+1].Start)
+			} else {
+				end = start + lastCueDuration
+			}
+		}
+		fmt.Fprintf(&b, "\n%s --> %s\n%s\n", formatVTTTime(start), formatVTTTime(end), line.Text)
+	}
+	return b.String()
+}
+
+// cutTag splits "[tag]rest" into the tag and the rest.
+func cutTag(s string) (tag, rest string, ok bool) {
+	if !strings.HasPrefix(s, "[") {
+		return "", "", false
+	}
+	end := strings.IndexByte(s, ']')
+	if end < 0 {
+		return "", "", false
+	}
+	return s[1:end], s[end+1:], true
+}
+
+// parseLRCTime parses "mm:ss", "mm:ss.xx" or "mm:ss:xx" into milliseconds.
+// The fraction may have one to three digits.
+func parseLRCTime(s string) (int64, error) {
+	parts := strings.Split(s, ":")
+	var fraction string
+	switch len(parts) {
+	case 2:
+		parts[1], fraction, _ = strings.Cut(parts[1], ".")
+	case 3:
+		fraction = parts[2]
+	default:
+		return 0, strconv.ErrSyntax
+	}
+	minutes, err := parseDigits(parts[0], 1, 3)
+	if err != nil {
+		return 0, err
+	}
+	secs, err := parseDigits(parts[1], 1, 2)
+	if err != nil || secs > 59 {
+		return 0, strconv.ErrSyntax
+	}
+	ms, err := parseFraction(fraction)
+	if err != nil {
+		return 0, err
+	}
+	return (minutes*60+secs)*1000 + ms, nil
+}
+
+// parseVTTTime parses "hh:mm:ss.ttt" or "mm:ss.ttt" into milliseconds.
+func parseVTTTime(s string) (int64, error) {
+	clock, fraction, ok := strings.Cut(s, ".")
+	if !ok || len(fraction) != 3 {
+		return 0, strconv.ErrSyntax
+	}
+	parts := strings.Split(clock, ":")
+	if len(parts) == 2 {
+		parts = append([]string{"0"}, parts...)
+	}
+	if len(parts) != 3 {
+		return 0, strconv.ErrSyntax
+	}
+	hours, err := parseDigits(parts[0], 1, 3)
+	if err != nil {
+		return 0, err
+	}
+	minutes, err := parseDigits(parts[1], 2, 2)
+	if err != nil || minutes > 59 {
+		return 0, strconv.ErrSyntax
+	}
+	secs, err := parseDigits(parts[2], 2, 2)
+	if err != nil || secs > 59 {
+		return 0, strconv.ErrSyntax
+	}
+	ms, err := parseFraction(fraction)
+	if err != nil {
+		return 0, err
+	}
+	return ((hours*60+minutes)*60+secs)*1000 + ms, nil
+}
+
+// parseDigits parses a number of min to max decimal digits.
+func parseDigits(s string, min, max int) (int64, error) {
+	if len(s) < min || len(s) > max || strings.Trim(s, "0123456789") != "" {
+		return 0, strconv.ErrSyntax
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// parseFraction parses up to three digits of a fraction of a second into
+// milliseconds.
+func parseFraction(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	n, err := parseDigits(s, 1, 3)
+	if err != nil {
+		return 0, err
+	}
+	for i := len(s); i < 3; i++ {
+		n *= 10
+	}
+	return n, nil
+}
+
+// formatLRCTime formats milliseconds as "mm:ss.xx", or "mm:ss.xxx" if the
+// time isn't a whole number of hundredths.
+func formatLRCTime(ms int64) string {
+	if ms%10 == 0 {
+		return fmt.Sprintf("%02d:%02d.%02d", ms/60000, ms/1000%60, ms%1000/10)
+	}
+	return fmt.Sprintf("%02d:%02d.%03d", ms/60000, ms/1000%60, ms%1000)
+}
+
+// formatVTTTime formats milliseconds as "hh:mm:ss.ttt".
+func formatVTTTime(ms int64) string {
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
+
+func millis(seconds float64) int64 {
+	return int64(math.Round(seconds * 1000))
+}
+
+func seconds(ms int64) float64 {
+	return float64(ms) / 1000
+}
@@ -0,0 +1,231 @@
+package synced
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+func TestParseLRC(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []models.SyncedLine
+		wantErr bool
+	}{
+		{
+			name: "lines",
+			data: "[00:01.00]Ooh baby\n[00:04.5]Can you hear me\n[01:02:250]Moan",
+			want: []models.SyncedLine{{Start: 1, Text: "Ooh baby"}, {Start: 4.5, Text: "Can you hear me"}, {Start: 62.25, Text: "Moan"}},
+		},
+		{
+			name: "metadata and pause",
+			data: "[ar:Muse]\n[ti:Uprising]\n\n[00:01.00]Paranoia\n[00:03.00]\n[00:05.00]Is in bloom",
+			want: []models.SyncedLine{{Start: 1, Text: "Paranoia"}, {Start: 3}, {Start: 5, Text: "Is in bloom"}},
+		},
+		{
+			name: "several timestamps",
+			data: "[00:12.00][00:45.00]Chorus\n[00:01.00]Intro\n[00:20.00] [00:30.00] Verse",
+			want: []models.SyncedLine{
+				{Start: 1, Text: "Intro"},
+				{Start: 12, Text: "Chorus"},
+				{Start: 20, Text: "Verse"},
+				{Start: 30, Text: "Verse"},
+				{Start: 45, Text: "Chorus"},
+			},
+		},
+		{
+			name: "text starting with brackets",
+			data: "[00:01.00][Chorus] Ooh",
+			want: []models.SyncedLine{{Start: 1, Text: "[Chorus] Ooh"}},
+		},
+		{
+			name: "positive offset",
+			data: "[00:02.00]One\n[offset:+500]\n[00:04.00]Two",
+			want: []models.SyncedLine{{Start: 1.5, Text: "One"}, {Start: 3.5, Text: "Two"}},
+		},
+		{
+			name: "negative offset",
+			data: "[offset:-1000]\n[00:02.00]One",
+			want: []models.SyncedLine{{Start: 3, Text: "One"}},
+		},
+		{
+			name: "offset before the start",
+			data: "[offset:1500]\n[00:01.00]One\n[00:04.00]Two",
+			want: []models.SyncedLine{{Start: 0, Text: "One"}, {Start: 2.5, Text: "Two"}},
+		},
+		{
+			name: "offset past several lines",
+			data: "[offset:+5000]\n[00:01.00]One\n[00:03.00]Two\n[00:07.00]Three",
+			want: []models.SyncedLine{{Start: 0, Text: "Two"}, {Start: 2, Text: "Three"}},
+		},
+		{
+			name: "offset past every line",
+			data: "[offset:+5000]\n[00:01.00]One\n[00:03.00]Two",
+			want: []models.SyncedLine{{Start: 0, Text: "Two"}},
+		},
+		{
+			name: "offset to the start of a line",
+			data: "[offset:+1000]\n[00:00.50]One\n[00:01.00]Two",
+			want: []models.SyncedLine{{Start: 0, Text: "Two"}},
+		},
+		{
+			name: "lines out of order",
+			data: "[00:02.00]One\n[00:01.00]Two",
+			want: []models.SyncedLine{{Start: 1, Text: "Two"}, {Start: 2, Text: "One"}},
+		},
+		{
+			name: "byte order mark",
+			data: "\ufeff[00:01.00]One",
+			want: []models.SyncedLine{{Start: 1, Text: "One"}},
+		},
+		{name: "missing timestamp", data: "Ooh baby", wantErr: true},
+		{name: "invalid timestamp", data: "[00:61.00]One", wantErr: true},
+		{name: "invalid offset", data: "[offset:soon]\n[00:01.00]One", wantErr: true},
+		{name: "same start", data: "[00:01.00][00:01.00]One", wantErr: true},
+		{name: "no lines", data: "[ar:Muse]", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLRC(tt.data)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalid) {
+					t.Fatalf("error = %v, want %v", err, ErrInvalid)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseVTT(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []models.SyncedLine
+		wantErr bool
+	}{
+		{
+			name: "cues",
+			data: "WEBVTT\n\n00:01.000 --> 00:03.500\nOoh baby\n\n00:00:04.000 --> 00:00:06.000 align:start\nCan you\nhear me\n",
+			want: []models.SyncedLine{{Start: 1, End: 3.5, Text: "Ooh baby"}, {Start: 4, End: 6, Text: "Can you\nhear me"}},
+		},
+		{
+			name: "identifiers, notes and CRLF",
+			data: "WEBVTT - lyrics\r\n\r\nNOTE made by hand\r\n\r\nfirst\r\n00:01.000 --> 00:02.000\r\nOne\r\n",
+			want: []models.SyncedLine{{Start: 1, End: 2, Text: "One"}},
+		},
+		{
+			name: "byte order mark",
+			data: "\ufeffWEBVTT\n\n00:01.000 --> 00:02.000\nOne\n",
+			want: []models.SyncedLine{{Start: 1, End: 2, Text: "One"}},
+		},
+		{name: "missing header", data: "00:01.000 --> 00:02.000\nOne", wantErr: true},
+		{name: "invalid timing", data: "WEBVTT\n\n00:01.000 -> 00:02.000\nOne", wantErr: true},
+		{name: "invalid start", data: "WEBVTT\n\n00:01.0 --> 00:02.000\nOne", wantErr: true},
+		{name: "end before start", data: "WEBVTT\n\n00:02.000 --> 00:01.000\nOne", wantErr: true},
+		{
+			name:    "cues out of order",
+			data:    "WEBVTT\n\n00:02.000 --> 00:03.000\nOne\n\n00:01.000 --> 00:01.500\nTwo",
+			wantErr: true,
+		},
+		{
+			name:    "overlapping cues",
+			data:    "WEBVTT\n\n00:01.000 --> 00:03.000\nOne\n\n00:02.000 --> 00:04.000\nTwo",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseVTT(tt.data)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalid) {
+					t.Fatalf("error = %v, want %v", err, ErrInvalid)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	lines := []models.SyncedLine{
+		{Start: 1, End: 2.5, Text: "Ooh baby"},
+		{Start: 4, Text: "Can you\nhear me"},
+		{Start: 6.125},
+		{Start: 8, Text: "Moan"},
+	}
+	tests := []struct {
+		name   string
+		encode func([]models.SyncedLine) string
+		want   string
+	}{
+		{
+			name:   "LRC",
+			encode: EncodeLRC,
+			want:   "[00:01.00]Ooh baby\n[00:02.50]\n[00:04.00]Can you hear me\n[00:06.125]\n[00:08.00]Moan\n",
+		},
+		{
+			name:   "WebVTT",
+			encode: EncodeVTT,
+			want: "WEBVTT\n\n00:00:01.000 --> 00:00:02.500\nOoh baby\n\n00:00:04.000 --> 00:00:06.125\nCan you\nhear me\n" +
+				"\n00:00:08.000 --> 00:00:13.000\nMoan\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.encode(lines); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAt(t *testing.T) {
+	lines := []models.SyncedLine{
+		{Start: 1, End: 2, Text: "One"},
+		{Start: 3, Text: "Two"},
+		{Start: 5},
+		{Start: 6, Text: "Three"},
+	}
+	tests := []struct {
+		name        string
+		t           float64
+		current     string
+		next        string
+		wantCurrent bool
+		wantNext    bool
+	}{
+		{name: "before the first line", t: 0, next: "One", wantNext: true},
+		{name: "at the start of a line", t: 1, current: "One", next: "Two", wantCurrent: true, wantNext: true},
+		{name: "after a line ended", t: 2.5, next: "Two", wantNext: true},
+		{name: "line without end", t: 4, current: "Two", next: "Three", wantCurrent: true, wantNext: true},
+		{name: "pause", t: 5.5, next: "Three", wantNext: true},
+		{name: "last line", t: 100, current: "Three", wantCurrent: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, next := At(lines, tt.t)
+			if (current != nil) != tt.wantCurrent || (current != nil && current.Text != tt.current) {
+				t.Errorf("current = %+v, want %q", current, tt.current)
+			}
+			if (next != nil) != tt.wantNext || (next != nil && next.Text != tt.next) {
+				t.Errorf("next = %+v, want %q", next, tt.next)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/notblinkyet/song-library-api/internal/lib/langtag"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// Limits of song fields and list query parameters.
+const (
+	MaxTitleLength = 255
+	MaxGroupLength = 255
+	MaxTextLength  = 100000
+	MaxLinkLength  = 2048
+	MaxQueryLength = 256
+	MaxLimit       = 1000
+	MinYear        = 1000
+	MaxYear        = 9999
+)
+
+// CreateSongRequest checks the body of POST /songs.
+func CreateSongRequest(v *Validator, req *models.CreateSongRequest) {
+	requiredString(v, "song", req.Title, MaxTitleLength)
+	requiredString(v, "group", req.Group, MaxGroupLength)
+}
+
+// SongRequest checks the fields of a song sent with PUT /songs/{id} or
+// produced by applying a PATCH to it, and brings its language tag to the
+// canonical form.
+func SongRequest(v *Validator, req *models.SongRequest) {
+	requiredString(v, "song", req.Title, MaxTitleLength)
+	requiredString(v, "group", req.Group, MaxGroupLength)
+	maxLength(v, "text", req.Text, MaxTextLength)
+	if req.Link != "" {
+		maxLength(v, "link", req.Link, MaxLinkLength)
+		v.Check(isURL(req.Link), "link", "must be an absolute http or https URL")
+	}
+	if !req.ReleaseDate.IsZero() {
+		year := req.ReleaseDate.Year()
+		v.Check(year >= MinYear, "releaseDate", fmt.Sprintf("must not be before year %d", MinYear))
+		v.Check(!req.ReleaseDate.After(time.Now().AddDate(0, 0, 1)), "releaseDate", "must not be in the future")
+	}
+	if req.Lang != "" {
+		lang, err := langtag.Canonical(req.Lang)
+		v.AddError("lang", err)
+		req.Lang = lang
+	}
+}
+
+// GroupRequest checks the body of POST /groups and PATCH /groups/{id}.
+func GroupRequest(v *Validator, req *models.GroupRequest) {
+	requiredString(v, "name", req.Name, MaxGroupLength)
+}
+
+// Filter checks the filters and pagination of a song listing. Field names are
+// the names of the query parameters.
+func Filter(v *Validator, filter *models.Filter) {
+	for _, title := range filter.Titles {
+		maxLength(v, "song", title, MaxTitleLength)
+	}
+	for _, group := range filter.Groups {
+		maxLength(v, "group", group, MaxGroupLength)
+	}
+	maxLength(v, "text", filter.Text, MaxQueryLength)
+	maxLength(v, "link", filter.Link, MaxLinkLength)
+	maxLength(v, "q", filter.Query, MaxQueryLength)
+
+	if filter.Year != 0 {
+		v.Check(filter.Year >= MinYear && filter.Year <= MaxYear, "year",
+			fmt.Sprintf("must be between %d and %d", MinYear, MaxYear))
+	}
+	if !filter.ReleaseDateFrom.IsZero() && !filter.ReleaseDateTo.IsZero() {
+		v.Check(!filter.ReleaseDateFrom.After(filter.ReleaseDateTo), "release_date_from",
+			"must not be after release_date_to")
+	}
+
+	v.Check(filter.Limit >= 0 && filter.Limit <= MaxLimit, "limit",
+		fmt.Sprintf("must be between 0 and %d", MaxLimit))
+	v.Check(filter.Offset >= 0, "offset", "must not be negative")
+}
+
+// TranslationRequest checks the body of PUT /songs/{id}/translations/{lang}.
+func TranslationRequest(v *Validator, req *models.TranslationRequest) {
+	requiredString(v, "text", req.Text, MaxTextLength)
+}
+
+// LyricsQuery checks the selection of GET /songs/{id}/lyrics. Ranges are
+// checked while parsing them.
+func LyricsQuery(v *Validator, query *models.LyricsQuery) {
+	if query.Section == "" {
+		return
+	}
+	switch query.Section {
+	case models.SectionIntro, models.SectionVerse, models.SectionPreChorus, models.SectionChorus,
+		models.SectionBridge, models.SectionOutro, models.SectionOther:
+	default:
+		v.Add("section", "must be one of intro, verse, pre-chorus, chorus, bridge, outro, other")
+	}
+}
+
+// requiredString checks that the value isn't blank and isn't too long.
+func requiredString(v *Validator, field, value string, limit int) {
+	if strings.TrimSpace(value) == "" {
+		v.Add(field, "is required")
+		return
+	}
+	maxLength(v, field, value, limit)
+}
+
+// maxLength checks that the value has at most limit characters.
+func maxLength(v *Validator, field, value string, limit int) {
+	v.Check(utf8.RuneCountInString(value) <= limit, field,
+		fmt.Sprintf("must be at most %d characters long", limit))
+}
+
+// isURL reports whether the value is an absolute http or https URL.
+func isURL(value string) bool {
+	u, err := url.ParseRequestURI(value)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,62 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+var (
+	ErrInvalid = errors.New("validation failed")
+)
+
+// Errors holds every problem found in a request, so that clients can fix
+// all of them at once.
+type Errors []models.FieldError
+
+func (e Errors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, fe := range e {
+		msgs = append(msgs, fe.Field+": "+fe.Message)
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalid, strings.Join(msgs, "; "))
+}
+
+func (e Errors) Unwrap() error {
+	return ErrInvalid
+}
+
+// Validator collects field errors of a request.
+type Validator struct {
+	errs Errors
+}
+
+// Add records a problem with the field.
+func (v *Validator) Add(field, message string) {
+	v.errs = append(v.errs, models.FieldError{Field: field, Message: message})
+}
+
+// AddError records err as a problem with the field. A nil err is ignored,
+// so the result of a parser can be passed directly.
+func (v *Validator) AddError(field string, err error) {
+	if err != nil {
+		v.Add(field, err.Error())
+	}
+}
+
+// Check records a problem with the field unless ok holds.
+func (v *Validator) Check(ok bool, field, message string) {
+	if !ok {
+		v.Add(field, message)
+	}
+}
+
+// Err returns the collected errors as Errors, or nil if there are none.
+func (v *Validator) Err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
@@ -0,0 +1,131 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+func TestValidator(t *testing.T) {
+	var v Validator
+	if err := v.Err(); err != nil {
+		t.Fatalf("no problems: error = %v, want nil", err)
+	}
+
+	v.Check(true, "song", "is required")
+	v.AddError("limit", nil)
+	v.Check(false, "song", "is required")
+	v.AddError("limit", errors.New("must be an integer"))
+	v.Add("limit", "must not be negative")
+
+	err := v.Err()
+	if !errors.Is(err, ErrInvalid) {
+		t.Fatalf("error = %v, want %v", err, ErrInvalid)
+	}
+	var fields Errors
+	if !errors.As(err, &fields) {
+		t.Fatalf("error %T is not Errors", err)
+	}
+	want := Errors{
+		{Field: "song", Message: "is required"},
+		{Field: "limit", Message: "must be an integer"},
+		{Field: "limit", Message: "must not be negative"},
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("errors = %+v, want %+v", fields, want)
+	}
+	wantMessage := "validation failed: song: is required; limit: must be an integer; limit: must not be negative"
+	if err.Error() != wantMessage {
+		t.Errorf("message = %q, want %q", err.Error(), wantMessage)
+	}
+}
+
+func TestSongRequest(t *testing.T) {
+	valid := func() models.SongRequest {
+		return models.SongRequest{
+			Title:       "Uprising",
+			Group:       "Muse",
+			ReleaseDate: time.Date(2009, 9, 7, 0, 0, 0, 0, time.UTC),
+			Link:        "https://example.com/uprising",
+		}
+	}
+
+	tests := []struct {
+		name       string
+		change     func(req *models.SongRequest)
+		wantFields []string
+	}{
+		{name: "valid", change: func(req *models.SongRequest) {}},
+		{name: "optional fields empty", change: func(req *models.SongRequest) { req.ReleaseDate, req.Link = time.Time{}, "" }},
+		{name: "blank title", change: func(req *models.SongRequest) { req.Title = "  " }, wantFields: []string{"song"}},
+		{
+			name:       "title and group missing",
+			change:     func(req *models.SongRequest) { req.Title, req.Group = "", "" },
+			wantFields: []string{"song", "group"},
+		},
+		{
+			name:       "title too long",
+			change:     func(req *models.SongRequest) { req.Title = strings.Repeat("я", MaxTitleLength+1) },
+			wantFields: []string{"song"},
+		},
+		{name: "title of the longest length", change: func(req *models.SongRequest) { req.Title = strings.Repeat("я", MaxTitleLength) }},
+		{name: "relative link", change: func(req *models.SongRequest) { req.Link = "/uprising" }, wantFields: []string{"link"}},
+		{name: "link of another scheme", change: func(req *models.SongRequest) { req.Link = "ftp://example.com" }, wantFields: []string{"link"}},
+		{
+			name:       "ancient release",
+			change:     func(req *models.SongRequest) { req.ReleaseDate = time.Date(999, 1, 1, 0, 0, 0, 0, time.UTC) },
+			wantFields: []string{"releaseDate"},
+		},
+		{
+			name:       "future release",
+			change:     func(req *models.SongRequest) { req.ReleaseDate = time.Now().AddDate(1, 0, 0) },
+			wantFields: []string{"releaseDate"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.change(&req)
+			var v Validator
+			SongRequest(&v, &req)
+
+			var fields []string
+			for _, fe := range v.errs {
+				fields = append(fields, fe.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("invalid fields = %q, want %q", fields, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestSongRequestLang(t *testing.T) {
+	tests := []struct {
+		lang    string
+		want    string
+		wantErr bool
+	}{
+		{lang: "", want: ""},
+		{lang: "ru", want: "ru"},
+		{lang: "pt_br", want: "pt-BR"},
+		{lang: "russian", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			req := models.SongRequest{Title: "Uprising", Group: "Muse", Lang: tt.lang}
+			var v Validator
+			SongRequest(&v, &req)
+			if err := v.Err(); (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && req.Lang != tt.want {
+				t.Errorf("lang = %q, want %q", req.Lang, tt.want)
+			}
+		})
+	}
+}
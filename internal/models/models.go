@@ -1,33 +1,515 @@
 package models
 
-import "time"
+import (
+	"strconv"
+	"strings"
+	"time"
+)
 
 type Song struct {
-	ID          int       `json:"id"`
+	ID          int        `json:"id"`
+	Title       string     `json:"song"`
+	Group       string     `json:"group"`
+	ReleaseDate time.Time  `json:"releaseDate"`
+	Text        string     `json:"text"`
+	Link        string     `json:"link"`
+	Lang        string     `json:"lang,omitempty"`
+	Version     int        `json:"version,omitempty"`
+	Status      string     `json:"status,omitempty"`
+	Sources     Sources    `json:"sources,omitempty"`
+	EnrichedAt  *time.Time `json:"enrichedAt,omitempty"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty"`
+	Rank        float64    `json:"rank,omitempty"`
+	Snippet     string     `json:"snippet,omitempty"` // HTML: escaped text with the matched words in <b></b>.
+}
+
+// Fields of a song filled in by the song info API.
+const (
+	FieldReleaseDate = "releaseDate"
+	FieldText        = "text"
+	FieldLink        = "link"
+)
+
+// Sources maps the fields of a song filled in by the song info API to the
+// names of the providers that supplied them.
+type Sources map[string]string
+
+// Without returns a copy of the sources without the field.
+func (s Sources) Without(field string) Sources {
+	if _, ok := s[field]; !ok {
+		return s
+	}
+	res := make(Sources, len(s))
+	for k, v := range s {
+		if k != field {
+			res[k] = v
+		}
+	}
+	return res
+}
+
+type Group struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	SongCount int    `json:"songCount"`
+}
+
+type GroupRequest struct {
+	Name string `json:"name"`
+}
+
+// Statuses of a song. A pending song waits for its details to be fetched
+// from the song info API, a failed one gave up waiting.
+const (
+	SongStatusPending = "pending"
+	SongStatusReady   = "ready"
+	SongStatusFailed  = "failed"
+)
+
+// Statuses of an enrichment job.
+const (
+	JobStatusQueued    = "queued"
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusFailed    = "failed"
+)
+
+// Job fetches the details of a song created with asynchronous enrichment.
+type Job struct {
+	ID        int       `json:"id"`
+	SongID    int       `json:"songId"`
+	Status    string    `json:"status"`
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"lastError,omitempty"`
+	NextRunAt time.Time `json:"nextRunAt"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// AcceptedSong is returned when a song has been stored and its details are
+// being fetched in the background.
+type AcceptedSong struct {
+	Id     int    `json:"id"`
+	JobID  int    `json:"jobId"`
+	Status string `json:"status"`
+}
+
+// Operations recorded in the song history.
+const (
+	OperationCreate  = "create"
+	OperationUpdate  = "update"
+	OperationDelete  = "delete"
+	OperationRestore = "restore"
+	OperationEnrich  = "enrich"
+	OperationRefresh = "refresh"
+)
+
+// SongVersion is the state of a song right after an operation on it.
+type SongVersion struct {
+	Version   int       `json:"version"`
+	Operation string    `json:"operation"`
+	CreatedAt time.Time `json:"createdAt"`
+	Song      Song      `json:"song"`
+}
+
+type CreateSongRequest struct {
+	Title string `json:"song"`
+	Group string `json:"group"`
+}
+
+// SongRequest holds the fields of a song that clients can change. It is the
+// body of PUT /songs/{id} and the document PATCH /songs/{id} is applied to.
+// Lang is the BCP 47 tag of the language of the text, if it is known.
+type SongRequest struct {
 	Title       string    `json:"song"`
 	Group       string    `json:"group"`
 	ReleaseDate time.Time `json:"releaseDate"`
 	Text        string    `json:"text"`
 	Link        string    `json:"link"`
+	Lang        string    `json:"lang,omitempty"`
 }
 
-type CreateSongRequest struct {
-	Title string `json:"song"`
-	Group string `json:"group"`
+// NewSongRequest returns the fields of the song that clients can change.
+func NewSongRequest(song *Song) *SongRequest {
+	return &SongRequest{
+		Title:       song.Title,
+		Group:       song.Group,
+		ReleaseDate: song.ReleaseDate,
+		Text:        song.Text,
+		Link:        song.Link,
+		Lang:        song.Lang,
+	}
+}
+
+// Apply overwrites the fields of the song that clients can change. Fields
+// that change no longer come from their provider, so their sources are dropped.
+func (r *SongRequest) Apply(song *Song) {
+	if !r.ReleaseDate.Equal(song.ReleaseDate) {
+		song.Sources = song.Sources.Without(FieldReleaseDate)
+	}
+	if r.Text != song.Text {
+		song.Sources = song.Sources.Without(FieldText)
+	}
+	if r.Link != song.Link {
+		song.Sources = song.Sources.Without(FieldLink)
+	}
+	song.Title = r.Title
+	song.Group = r.Group
+	song.ReleaseDate = r.ReleaseDate
+	song.Text = r.Text
+	song.Link = r.Link
+	song.Lang = r.Lang
 }
 
 type Filter struct {
-	Title       string
-	Group       string
+	Titles          []string
+	TitleMatch      string
+	Groups          []string
+	GroupMatch      string
+	GroupID         int
+	ReleaseDate     time.Time
+	ReleaseDateFrom time.Time
+	ReleaseDateTo   time.Time
+	Year            int
+	Text            string
+	Link            string
+	Query           string
+	Limit           int
+	Offset          int
+	Sort            []SortField
+	Cursor          *Cursor
+	WithTotal       bool
+	Deleted         bool
+}
+
+// Ways string filters are matched. Prefix and substring matching ignore case.
+const (
+	MatchExact    = "exact"
+	MatchPrefix   = "prefix"
+	MatchContains = "contains"
+)
+
+// Fields songs can be sorted by.
+const (
+	SortID          = "id"
+	SortTitle       = "title"
+	SortGroup       = "group"
+	SortReleaseDate = "release_date"
+)
+
+// SortField is one key of a song ordering.
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// SortString formats the ordering the way it is written in the sort query
+// parameter, e.g. "-release_date,title".
+func SortString(sort []SortField) string {
+	keys := make([]string, 0, len(sort))
+	for _, field := range sort {
+		if field.Desc {
+			keys = append(keys, "-"+field.Field)
+		} else {
+			keys = append(keys, field.Field)
+		}
+	}
+	return strings.Join(keys, ",")
+}
+
+// Ordering returns the sort keys of the filter, tie-broken by id so that the
+// order of songs is always stable.
+func (f *Filter) Ordering() []SortField {
+	for _, field := range f.Sort {
+		if field.Field == SortID {
+			return f.Sort
+		}
+	}
+	ordering := make([]SortField, 0, len(f.Sort)+1)
+	ordering = append(ordering, f.Sort...)
+	return append(ordering, SortField{Field: SortID})
+}
+
+// SortValue returns the value of the sort field for the song, formatted so
+// that values of the same field compare in the order of the field.
+func (s *Song) SortValue(field string) string {
+	switch field {
+	case SortTitle:
+		return s.Title
+	case SortGroup:
+		return s.Group
+	case SortReleaseDate:
+		return s.ReleaseDate.Format(time.DateOnly)
+	default:
+		return strconv.Itoa(s.ID)
+	}
+}
+
+// Cursor points at the last song of a page; the next page starts right after it.
+// Values hold the sort values of that song for the ordering described by Sort.
+type Cursor struct {
+	ID     int      `json:"id"`
+	Sort   string   `json:"sort,omitempty"`
+	Values []string `json:"values,omitempty"`
+}
+
+type SongPage struct {
+	Items      []Song `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	Total      *int   `json:"total,omitempty"`
+	HasMore    bool   `json:"-"`
+}
+
+type Verse struct {
+	Verse    string `json:"verse"`
+	Original string `json:"original,omitempty"` // The verse of the original text, if Verse is translated.
+}
+
+// SongVerses holds a range of verses together with the version of the song they were read from.
+// Translation is the translation the verses are taken from, or nil for the original text.
+type SongVerses struct {
+	Version     int
+	Verses      []*Verse
+	Translation *Translation
+}
+
+// Types of lyrics sections.
+const (
+	SectionIntro     = "intro"
+	SectionVerse     = "verse"
+	SectionPreChorus = "pre-chorus"
+	SectionChorus    = "chorus"
+	SectionBridge    = "bridge"
+	SectionOutro     = "outro"
+	SectionOther     = "other"
+)
+
+// Section is a part of the lyrics of a song, such as a verse or a chorus.
+type Section struct {
+	Type      string   `json:"type"`
+	Index     int      `json:"index"`           // 1-based number among the sections of the same type.
+	Label     string   `json:"label,omitempty"` // Label the section had in the text, e.g. "Chorus 2".
+	FirstLine int      `json:"firstLine"`       // 1-based number of the first line in the whole song.
+	Lines     []string `json:"lines"`
+}
+
+// Range is a range of verses or lines numbered from 1. To is 0 if the range
+// lasts to the end.
+type Range struct {
+	From int
+	To   int
+}
+
+// Contains reports whether n is in the range.
+func (r Range) Contains(n int) bool {
+	return n >= r.From && (r.To == 0 || n <= r.To)
+}
+
+// LyricsQuery selects parts of the lyrics of a song. Only the lines matching
+// every given condition are selected.
+type LyricsQuery struct {
+	Verses   []Range // Sections by their number in the song.
+	Lines    []Range // Lines by their number in the song.
+	Section  string  // Type of the sections.
+	Language LanguageQuery
+}
+
+// LanguageQuery selects the language of lyrics: the translation given by
+// Lang, or else the one Accept-Language prefers. The original text is used
+// if neither is set or no translation is acceptable.
+type LanguageQuery struct {
+	Lang           string
+	AcceptLanguage string
+}
+
+// Line is a line of lyrics with its 1-based number in the whole song.
+type Line struct {
+	Number int    `json:"number"`
+	Text   string `json:"text"`
+}
+
+// LyricsVerse is a section of lyrics with its 1-based number in the song.
+type LyricsVerse struct {
+	Number int    `json:"number"`
+	Type   string `json:"type"`
+	Index  int    `json:"index"`
+	Label  string `json:"label,omitempty"`
+	Lines  []Line `json:"lines"`
+}
+
+// Lyrics holds the selected parts of the lyrics of a song, with the total
+// number of verses and lines for paginating through them. Lang and
+// LangVersion are set if the lyrics are taken from a translation.
+type Lyrics struct {
+	SongID      int           `json:"songId"`
+	Version     int           `json:"version"`
+	Lang        string        `json:"lang,omitempty"`
+	LangVersion int           `json:"langVersion,omitempty"`
+	TotalVerses int           `json:"total_verses"`
+	TotalLines  int           `json:"total_lines"`
+	Verses      []LyricsVerse `json:"verses"`
+}
+
+// SyncedLine is a line of lyrics with the time in seconds from the start of
+// the song when it is sung. End is 0 if the line lasts until the next one.
+// Lines without text mark pauses.
+type SyncedLine struct {
+	Start float64 `json:"start"`
+	End   float64 `json:"end,omitempty"`
+	Text  string  `json:"text"`
+}
+
+// SyncedLyrics are the lyrics of a song synchronised with its playback,
+// ordered by the start of the lines.
+type SyncedLyrics struct {
+	SongID    int          `json:"songId"`
+	Lines     []SyncedLine `json:"lines"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+}
+
+// SyncedLyricsRequest is the body of PUT /songs/{id}/lyrics/synced in JSON.
+type SyncedLyricsRequest struct {
+	Lines []SyncedLine `json:"lines"`
+}
+
+// LyricsPosition holds the line sung at a playback position and the next
+// one. Current is nil before the first line and during pauses, Next is nil
+// after the last line.
+type LyricsPosition struct {
+	SongID  int         `json:"songId"`
+	Time    float64     `json:"t"`
+	Current *SyncedLine `json:"current"`
+	Next    *SyncedLine `json:"next"`
+}
+
+// Translation is the text of a song in another language. Its verses are
+// aligned with the verses of the text of the song of SongVersion; Outdated
+// is set if the text of the song has changed since.
+type Translation struct {
+	SongID      int       `json:"songId"`
+	Lang        string    `json:"lang"`
+	Text        string    `json:"text"`
+	Version     int       `json:"version"`
+	SongVersion int       `json:"songVersion"`
+	Outdated    bool      `json:"outdated"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// TranslationRequest is the body of PUT /songs/{id}/translations/{lang}.
+type TranslationRequest struct {
+	Text string `json:"text"`
+}
+
+// ChordChart is a ChordPro document with the chords of a song, kept apart
+// from its plain text.
+type ChordChart struct {
+	SongID    int       `json:"songId"`
+	Source    string    `json:"source"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ChordChartRequest is the body of PUT /songs/{id}/chords in JSON.
+type ChordChartRequest struct {
+	Source string `json:"source"`
+}
+
+// ChordSheet is a parsed ChordPro document. Lyrics holds its text without
+// chords, directives and tabs.
+type ChordSheet struct {
+	Title     string         `json:"title,omitempty"`
+	Subtitle  string         `json:"subtitle,omitempty"`
+	Artist    string         `json:"artist,omitempty"`
+	Key       string         `json:"key,omitempty"`
+	Capo      int            `json:"capo,omitempty"`
+	Transpose int            `json:"transpose,omitempty"`
+	Sections  []ChordSection `json:"sections"`
+	Lyrics    string         `json:"lyrics"`
+}
+
+// ChordSection is a part of a chord sheet. Type is empty for lines outside
+// of any section.
+type ChordSection struct {
+	Type  string      `json:"type,omitempty"`
+	Label string      `json:"label,omitempty"`
+	Lines []ChordLine `json:"lines"`
+}
+
+// ChordLine is a line of lyrics split at its chords, a comment or a line of
+// a tab.
+type ChordLine struct {
+	Segments []ChordSegment `json:"segments,omitempty"`
+	Comment  string         `json:"comment,omitempty"`
+	Tab      string         `json:"tab,omitempty"`
+}
+
+// ChordSegment is a chord with the lyrics sung on it. Chord is empty for the
+// lyrics before the first chord of a line.
+type ChordSegment struct {
+	Chord string `json:"chord,omitempty"`
+	Text  string `json:"text"`
+}
+
+// SongSections is the parsed structure of the lyrics of a song, valid for
+// the given version of the song.
+type SongSections struct {
+	SongID   int       `json:"songId"`
+	Version  int       `json:"version"`
+	Sections []Section `json:"sections"`
+}
+
+// UpstreamStatus describes the health of an external API as seen by the
+// circuit breaker of its client.
+type UpstreamStatus struct {
+	Name                string     `json:"name"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	LastError           string     `json:"lastError,omitempty"`
+	RetryAt             *time.Time `json:"retryAt,omitempty"`
+}
+
+// FieldChange is a field of a song changed by a refresh.
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+// SongRefresh is the outcome of fetching the details of a song again.
+type SongRefresh struct {
+	Song    *Song         `json:"song"`
+	Changes []FieldChange `json:"changes"`
+	Applied bool          `json:"applied"`
+}
+
+// CachedSongInfo is a cached answer of the song info API. NotFound marks a
+// song the API rejected, whose details are empty.
+type CachedSongInfo struct {
+	Key         string
+	NotFound    bool
 	ReleaseDate time.Time
 	Text        string
 	Link        string
-	Limit       int
-	Offset      int
+	Sources     Sources
+	ExpiresAt   time.Time
 }
 
-type Verse struct {
-	Verse string `json:"verse"`
+// Problem is an RFC 7807 problem details object returned with every error
+// response. Code is a stable machine-readable error code.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code"`
+	RequestID string       `json:"requestId,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describes a problem with one field or query parameter of a request.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 type Id struct {
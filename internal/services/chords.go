@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/notblinkyet/song-library-api/internal/lib/chordpro"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// ReadChordSheet returns the parsed chord chart of the song with its chords
+// moved by transpose semitones. If capo is not nil, the chords are also
+// rewritten for playing with the capo on that fret instead of the one of the
+// chart, so that they sound the same.
+func (s *SongLibraryService) ReadChordSheet(ctx context.Context, id, transpose int, capo *int) (*models.ChordSheet, error) {
+	s.log.Info("reading chord sheet", slog.Int("id", id), slog.Int("transpose", transpose))
+
+	chart, err := s.SingStorage.ReadChordChart(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	sheet, err := chordpro.Parse(chart.Source)
+	if err != nil {
+		return nil, err
+	}
+	shift := transpose
+	if capo != nil {
+		shift += sheet.Capo - *capo
+	}
+	sheet = chordpro.Transpose(sheet, shift)
+	sheet.Transpose = transpose
+	if capo != nil {
+		sheet.Capo = *capo
+	}
+	return sheet, nil
+}
+
+// SaveChordChart checks that the source is a valid ChordPro document and
+// attaches it to the song, replacing the previous chart. The lyrics of the
+// song are kept as they are.
+func (s *SongLibraryService) SaveChordChart(ctx context.Context, id int, source string) (*models.ChordChart, error) {
+	s.log.Info("saving chord chart", slog.Int("id", id), slog.Int("size", len(source)))
+
+	if _, err := chordpro.Parse(source); err != nil {
+		return nil, err
+	}
+	chart := &models.ChordChart{SongID: id, Source: source}
+	if err := s.SingStorage.SaveChordChart(ctx, chart); err != nil {
+		return nil, err
+	}
+	return chart, nil
+}
+
+// DeleteChordChart removes the chord chart of the song.
+func (s *SongLibraryService) DeleteChordChart(ctx context.Context, id int) error {
+	s.log.Info("deleting chord chart", slog.Int("id", id))
+	return s.SingStorage.DeleteChordChart(ctx, id)
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/database"
+	"github.com/notblinkyet/song-library-api/internal/lib/api"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// enrichmentLease is how long a worker may hold a job before other workers
+// consider it abandoned and take it over.
+const enrichmentLease = 5 * time.Minute
+
+// EnrichmentOptions configure the workers that fetch song details in the background.
+type EnrichmentOptions struct {
+	Workers      int           // Jobs processed concurrently.
+	MaxAttempts  int           // Attempts after which a job fails for good.
+	RetryDelay   time.Duration // Delay before the first retry, doubled for every next one.
+	PollInterval time.Duration // How often the queue is checked for due jobs.
+}
+
+// CreateAsync stores the song with the pending status and queues a job that
+// fetches its details from the external API, so the song is created even if
+// the API is down.
+func (s *SongLibraryService) CreateAsync(ctx context.Context, req *models.CreateSongRequest) (*models.Job, error) {
+	s.log.Info("saving pending song in the database")
+
+	job, err := s.SingStorage.CreatePendingSong(ctx, &models.Song{Title: req.Title, Group: req.Group})
+	if err != nil {
+		s.log.Error("failed to insert pending song into database", sl.Error(err))
+		return nil, err
+	}
+	s.log.Debug("queued enrichment of the song", slog.Int("id", job.SongID), slog.Int("jobID", job.ID))
+
+	// Let an idle worker pick the job up right away.
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+// ReadJob retrieves an enrichment job by its ID.
+func (s *SongLibraryService) ReadJob(ctx context.Context, id int) (*models.Job, error) {
+	s.log.Info("retrieving job by id")
+	return s.SingStorage.ReadJob(ctx, id)
+}
+
+// RunEnrichment runs the workers that fetch the details of pending songs until
+// ctx is cancelled. Failed jobs are retried with exponential backoff until
+// they run out of attempts.
+func (s *SongLibraryService) RunEnrichment(ctx context.Context, opts EnrichmentOptions) {
+	var wg sync.WaitGroup
+	for i := 0; i < max(opts.Workers, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.enrichmentWorker(ctx, opts)
+		}()
+	}
+	wg.Wait()
+}
+
+// enrichmentWorker processes due jobs one by one, and waits for a new job or
+// the next poll when there are none.
+func (s *SongLibraryService) enrichmentWorker(ctx context.Context, opts EnrichmentOptions) {
+	ticker := time.NewTicker(opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		job, err := s.SingStorage.ClaimJob(ctx, enrichmentLease)
+		if err != nil && ctx.Err() == nil {
+			s.log.Error("failed to claim enrichment job", sl.Error(err))
+		}
+		if job != nil {
+			s.enrich(ctx, job, opts)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// enrich fetches the details of the song of the job and stores them.
+func (s *SongLibraryService) enrich(ctx context.Context, job *models.Job, opts EnrichmentOptions) {
+	log := s.log.With(slog.Int("jobID", job.ID), slog.Int("songID", job.SongID), slog.Int("attempt", job.Attempts))
+
+	song, err := s.SingStorage.ReadByID(ctx, job.SongID)
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			s.postponeJob(ctx, log, job)
+			return
+		}
+		s.retryJob(ctx, log, job, err, opts)
+		return
+	}
+
+	info, err := s.ApiClient.GetMoreAboutSong(ctx, &models.CreateSongRequest{Title: song.Title, Group: song.Group})
+	if err != nil {
+		if ctx.Err() != nil {
+			// Shutting down: the job is taken over once its lease expires.
+			return
+		}
+		if errors.Is(err, api.ErrBadRequest) {
+			// The API doesn't know the song, asking again won't help.
+			s.failJob(ctx, log, job, err)
+			return
+		}
+		s.retryJob(ctx, log, job, err, opts)
+		return
+	}
+
+	err = s.SingStorage.CompleteJob(ctx, job.ID, info)
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			s.postponeJob(ctx, log, job)
+			return
+		}
+		s.retryJob(ctx, log, job, err, opts)
+		return
+	}
+	log.Info("song enriched")
+}
+
+// retryJob schedules the job to run again, or fails it if it has run out of attempts.
+func (s *SongLibraryService) retryJob(ctx context.Context, log *slog.Logger, job *models.Job, cause error, opts EnrichmentOptions) {
+	if job.Attempts >= opts.MaxAttempts {
+		s.failJob(ctx, log, job, cause)
+		return
+	}
+
+	delay := opts.RetryDelay
+	for i := 1; i < job.Attempts; i++ {
+		delay *= 2
+	}
+	log.Warn("song enrichment failed, will retry", slog.Duration("delay", delay), sl.Error(cause))
+	if err := s.SingStorage.RetryJob(ctx, job.ID, cause.Error(), time.Now().Add(delay)); err != nil {
+		log.Error("failed to reschedule enrichment job", sl.Error(err))
+	}
+}
+
+// postponeJob puts back the job of a song that has been moved to the trash
+// since the job was claimed. The job waits until the song is restored, and
+// goes away with the song if it is purged instead.
+func (s *SongLibraryService) postponeJob(ctx context.Context, log *slog.Logger, job *models.Job) {
+	log.Info("song is in the trash, postponing its enrichment")
+	err := s.SingStorage.RetryJob(ctx, job.ID, "song is in the trash", time.Now())
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		log.Error("failed to postpone enrichment job", sl.Error(err))
+	}
+}
+
+// failJob gives up on the job and marks its song as failed.
+func (s *SongLibraryService) failJob(ctx context.Context, log *slog.Logger, job *models.Job, cause error) {
+	log.Error("song enrichment failed", sl.Error(cause))
+	if err := s.SingStorage.FailJob(ctx, job.ID, cause.Error()); err != nil {
+		log.Error("failed to mark enrichment job as failed", sl.Error(err))
+	}
+}
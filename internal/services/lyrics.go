@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/notblinkyet/song-library-api/internal/lib/lyrics"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// ReadLyrics returns the parts of the lyrics of the song selected by the
+// query, grouped by sections, which are numbered as verses. A range that
+// starts beyond the last verse or line is an error, while its end is
+// limited to the last one, so clients can page through the lyrics. If the
+// language of the query selects a translation, its lyrics are returned.
+func (s *SongLibraryService) ReadLyrics(ctx context.Context, id int, query *models.LyricsQuery) (*models.Lyrics, error) {
+	s.log.Info("reading lyrics of the song", slog.Int("id", id))
+
+	song, err := s.SingStorage.ReadByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	translation, err := s.translation(ctx, song, &query.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	// A translation is parsed on every request, while the sections of the
+	// original text are kept.
+	res := &models.Lyrics{
+		SongID:  song.ID,
+		Version: song.Version,
+		Verses:  []models.LyricsVerse{},
+	}
+	var sections []models.Section
+	if translation != nil {
+		res.Lang = translation.Lang
+		res.LangVersion = translation.Version
+		sections = lyrics.Parse(translation.Text)
+	} else {
+		stored, err := s.sections(ctx, song)
+		if err != nil {
+			return nil, err
+		}
+		sections = stored.Sections
+	}
+	res.TotalVerses = len(sections)
+	for _, section := range sections {
+		res.TotalLines += len(section.Lines)
+	}
+	if !startsWithin(query.Verses, res.TotalVerses) {
+		return nil, ErrVerseOutOfBound
+	}
+	if !startsWithin(query.Lines, res.TotalLines) {
+		return nil, ErrLineOutOfBound
+	}
+
+	for i, section := range sections {
+		number := i + 1
+		if !inRanges(query.Verses, number) || (query.Section != "" && section.Type != query.Section) {
+			continue
+		}
+		verse := models.LyricsVerse{
+			Number: number,
+			Type:   section.Type,
+			Index:  section.Index,
+			Label:  section.Label,
+		}
+		for j, text := range section.Lines {
+			line := section.FirstLine + j
+			if inRanges(query.Lines, line) {
+				verse.Lines = append(verse.Lines, models.Line{Number: line, Text: text})
+			}
+		}
+		if len(verse.Lines) > 0 {
+			res.Verses = append(res.Verses, verse)
+		}
+	}
+	return res, nil
+}
+
+// inRanges reports whether n is in any of the ranges, or whether there are
+// no ranges at all.
+func inRanges(ranges []models.Range, n int) bool {
+	if len(ranges) == 0 {
+		return true
+	}
+	for _, r := range ranges {
+		if r.Contains(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// startsWithin reports whether every range starts at most at total.
+func startsWithin(ranges []models.Range, total int) bool {
+	for _, r := range ranges {
+		if r.From > total {
+			return false
+		}
+	}
+	return true
+}
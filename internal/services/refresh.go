@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/notblinkyet/song-library-api/internal/lib/api"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// RefreshOptions configure the scheduled refresh of song details.
+type RefreshOptions struct {
+	Interval time.Duration // How often stale songs are looked for.
+	MaxAge   time.Duration // Age after which the details of a song are fetched again.
+	EmptyAge time.Duration // Age after which empty details are fetched again.
+	Batch    int           // Songs refreshed per run.
+}
+
+// RefreshSong fetches the details of the song from the external API again
+// and returns the fields that change. Fields the user has edited are kept
+// unless force is set; empty fields and fields supplied by a provider are
+// replaced. With dryRun the changes are only reported.
+func (s *SongLibraryService) RefreshSong(ctx context.Context, id int, force, dryRun bool) (*models.SongRefresh, error) {
+	s.log.Info("refreshing song details", slog.Int("id", id), slog.Bool("dryRun", dryRun))
+
+	song, err := s.SingStorage.ReadByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.refresh(ctx, song, force, dryRun)
+}
+
+// refresh fetches the details of the song and applies the changes unless dryRun.
+// The cached answer of the API is skipped, as it may be as old as the song.
+func (s *SongLibraryService) refresh(ctx context.Context, song *models.Song, force, dryRun bool) (*models.SongRefresh, error) {
+	info, err := s.ApiClient.GetMoreAboutSong(api.BypassCache(ctx), &models.CreateSongRequest{Title: song.Title, Group: song.Group})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &models.SongRefresh{Changes: []models.FieldChange{}, Applied: !dryRun}
+	updated := *song
+	updated.Sources = models.Sources{}
+	for field, provider := range song.Sources {
+		updated.Sources[field] = provider
+	}
+
+	// replaceable reports whether the field may take the fetched value.
+	replaceable := func(field string, empty bool) bool {
+		_, fromProvider := song.Sources[field]
+		return force || empty || fromProvider
+	}
+	if !info.ReleaseDate.IsZero() && replaceable(models.FieldReleaseDate, song.ReleaseDate.IsZero()) &&
+		!info.ReleaseDate.Equal(song.ReleaseDate) {
+		res.Changes = append(res.Changes, models.FieldChange{Field: models.FieldReleaseDate, Before: song.ReleaseDate, After: info.ReleaseDate})
+		updated.ReleaseDate = info.ReleaseDate
+		setSource(updated.Sources, info.Sources, models.FieldReleaseDate)
+	}
+	if info.Text != "" && replaceable(models.FieldText, song.Text == "") && info.Text != song.Text {
+		res.Changes = append(res.Changes, models.FieldChange{Field: models.FieldText, Before: song.Text, After: info.Text})
+		updated.Text = info.Text
+		setSource(updated.Sources, info.Sources, models.FieldText)
+	}
+	if info.Link != "" && replaceable(models.FieldLink, song.Link == "") && info.Link != song.Link {
+		res.Changes = append(res.Changes, models.FieldChange{Field: models.FieldLink, Before: song.Link, After: info.Link})
+		updated.Link = info.Link
+		setSource(updated.Sources, info.Sources, models.FieldLink)
+	}
+	res.Song = &updated
+
+	if dryRun {
+		return res, nil
+	}
+	if len(res.Changes) == 0 {
+		// Nothing new, only remember that the details are up to date.
+		if err = s.SingStorage.MarkSongRefreshed(ctx, song.ID); err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		updated.EnrichedAt = &now
+		return res, nil
+	}
+	if err = s.SingStorage.RefreshSong(ctx, &updated); err != nil {
+		return nil, err
+	}
+	updated.Status = models.SongStatusReady
+	return res, nil
+}
+
+// setSource records the provider of the field, or forgets it if unknown.
+func setSource(dst, src models.Sources, field string) {
+	if provider, ok := src[field]; ok {
+		dst[field] = provider
+	} else {
+		delete(dst, field)
+	}
+}
+
+// RunRefresh refreshes the details of stale songs every interval until ctx
+// is cancelled. Fields the user has edited are kept.
+func (s *SongLibraryService) RunRefresh(ctx context.Context, opts RefreshOptions) {
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.refreshStale(ctx, opts)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refreshStale refreshes one batch of stale songs.
+func (s *SongLibraryService) refreshStale(ctx context.Context, opts RefreshOptions) {
+	now := time.Now()
+	songs, err := s.SingStorage.ReadStaleSongs(ctx, now.Add(-opts.MaxAge), now.Add(-opts.EmptyAge), opts.Batch)
+	if err != nil {
+		s.log.Error("failed to read stale songs", sl.Error(err))
+		return
+	}
+
+	refreshed, changed := 0, 0
+	for i := range songs {
+		res, err := s.refresh(ctx, &songs[i], false, false)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, api.ErrCircuitOpen) {
+				// The rest of the batch would fail the same way.
+				s.log.Warn("stopped refreshing songs", sl.Error(err))
+				break
+			}
+			if errors.Is(err, api.ErrBadRequest) {
+				// Don't ask about the song again until it is stale again.
+				err = s.SingStorage.MarkSongRefreshed(ctx, songs[i].ID)
+			}
+			if err != nil {
+				s.log.Warn("failed to refresh song", slog.Int("id", songs[i].ID), sl.Error(err))
+				continue
+			}
+		}
+		refreshed++
+		if res != nil && len(res.Changes) > 0 {
+			changed++
+		}
+	}
+	if refreshed > 0 {
+		s.log.Info("refreshed songs", slog.Int("count", refreshed), slog.Int("changed", changed))
+	}
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/notblinkyet/song-library-api/internal/lib/lyrics"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// ReadSections returns the structure of the lyrics of the song. The parsed
+// structure is stored and reused until the song changes.
+func (s *SongLibraryService) ReadSections(ctx context.Context, id int) (*models.SongSections, error) {
+	s.log.Info("reading sections of the song", slog.Int("id", id))
+
+	song, err := s.SingStorage.ReadByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.sections(ctx, song)
+}
+
+// sections returns the stored structure of the lyrics of the song, parsing
+// and storing it again if it was parsed from an older version. Failures to
+// store it are logged and otherwise ignored.
+func (s *SongLibraryService) sections(ctx context.Context, song *models.Song) (*models.SongSections, error) {
+	stored, err := s.SingStorage.ReadSongSections(ctx, song.ID)
+	if err != nil {
+		return nil, err
+	}
+	if stored != nil && stored.Version == song.Version {
+		return stored, nil
+	}
+
+	res := &models.SongSections{
+		SongID:   song.ID,
+		Version:  song.Version,
+		Sections: lyrics.Parse(song.Text),
+	}
+	s.log.Debug("parsed song sections", slog.Int("id", song.ID), slog.Int("sections", len(res.Sections)))
+	if err = s.SingStorage.SaveSongSections(ctx, res); err != nil {
+		s.log.Warn("failed to save song sections", slog.Int("id", song.ID), sl.Error(err))
+	}
+	return res, nil
+}
@@ -1,24 +1,31 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"log/slog"
-	"strings"
+	"time"
 
 	"github.com/notblinkyet/song-library-api/internal/database"
 	"github.com/notblinkyet/song-library-api/internal/lib/api"
+	"github.com/notblinkyet/song-library-api/internal/lib/cursor"
 	"github.com/notblinkyet/song-library-api/internal/lib/sl"
 	"github.com/notblinkyet/song-library-api/internal/models"
 )
 
 // Predefined error
 var (
-	ErrVerseOutOfBound = errors.New("this song doesn't have so many verses")
+	ErrVerseOutOfBound  = errors.New("this song doesn't have so many verses")
+	ErrLineOutOfBound   = errors.New("this song doesn't have so many lines")
+	ErrCursorWithSearch = errors.New("cursor pagination is not supported together with full-text search")
+
+	ErrTranslationMisaligned = errors.New("translation is not aligned with the verses of the song")
 )
 
 // ApiClient defines the interface for external API interactions.
 type ApiClient interface {
-	GetMoreAboutSong(req *models.CreateSongRequest) (*models.Song, error)
+	GetMoreAboutSong(ctx context.Context, req *models.CreateSongRequest) (*models.Song, error)
+	Status() []models.UpstreamStatus
 }
 
 // SongLibraryService handles all business logic for song-related operations.
@@ -26,6 +33,7 @@ type SongLibraryService struct {
 	SingStorage database.Storage // Database storage for songs.
 	ApiClient   ApiClient        // External API client.
 	log         *slog.Logger     // Logger for structured logging.
+	wake        chan struct{}    // Wakes an enrichment worker when a job is queued.
 }
 
 // NewSongLibraryService initializes and returns a new SongLibraryService instance.
@@ -34,15 +42,16 @@ func NewSongLibraryService(SingStorage database.Storage, a ApiClient, log *slog.
 		SingStorage: SingStorage,
 		ApiClient:   a,
 		log:         log,
+		wake:        make(chan struct{}, 1),
 	}
 }
 
 // Create handles the creation of a new song by saving it to the database.
-func (s *SongLibraryService) Create(req *models.CreateSongRequest) (int, error) {
+func (s *SongLibraryService) Create(ctx context.Context, req *models.CreateSongRequest) (int, error) {
 	s.log.Info("saving song in the database")
 
 	// Retrieve additional song details from the external API.
-	song, err := s.ApiClient.GetMoreAboutSong(req)
+	song, err := s.ApiClient.GetMoreAboutSong(ctx, req)
 	if err != nil {
 		if errors.Is(err, api.ErrBadRequest) {
 			// Log the error if the API request is invalid.
@@ -58,7 +67,7 @@ func (s *SongLibraryService) Create(req *models.CreateSongRequest) (int, error)
 	s.log.Debug("retrieved information about song", slog.Any("song", song))
 
 	// Save the song to the database and return the new song's ID.
-	id, err := s.SingStorage.CreateSong(song)
+	id, err := s.SingStorage.CreateSong(ctx, song)
 	if err != nil {
 		// Log any database insertion errors.
 		s.log.Error("failed to insert song into database", sl.Error(err))
@@ -71,62 +80,258 @@ func (s *SongLibraryService) Create(req *models.CreateSongRequest) (int, error)
 	return id, nil
 }
 
-// ReadFilteredSongs retrieves a list of songs that match the specified filter criteria.
-func (s *SongLibraryService) ReadFilteredSongs(filter *models.Filter) ([]models.Song, error) {
+// ReadFilteredSongs retrieves a page of songs that match the specified filter criteria.
+// When the filter has a limit, one extra song is requested to find out whether
+// there is a next page, and the cursor pointing at it is returned with the page.
+func (s *SongLibraryService) ReadFilteredSongs(ctx context.Context, filter *models.Filter) (*models.SongPage, error) {
 	s.log.Info("reading songs using filter")
-	return s.SingStorage.ReadFilteredSongs(filter)
+
+	// Search results without explicit sort are ordered by rank, which a cursor
+	// can't point into.
+	cursorable := filter.Query == "" || len(filter.Sort) > 0
+	if filter.Cursor != nil {
+		if !cursorable {
+			return nil, ErrCursorWithSearch
+		}
+		// A cursor is only valid for the ordering it was issued for.
+		if filter.Cursor.Sort != models.SortString(filter.Sort) || len(filter.Cursor.Values) != len(filter.Ordering()) {
+			return nil, cursor.ErrInvalidCursor
+		}
+	}
+
+	query := *filter
+	if query.Limit > 0 {
+		query.Limit++
+	}
+
+	songs, err := s.SingStorage.ReadFilteredSongs(ctx, &query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.SongPage{Items: songs}
+	if page.Items == nil {
+		page.Items = []models.Song{}
+	}
+	if filter.Limit > 0 && len(songs) > filter.Limit {
+		page.Items = songs[:filter.Limit]
+		page.HasMore = true
+		if cursorable {
+			page.NextCursor = cursor.Encode(nextCursor(filter, &page.Items[len(page.Items)-1]))
+		}
+	}
+
+	if filter.WithTotal {
+		total, err := s.SingStorage.CountSongs(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		page.Total = &total
+	}
+
+	return page, nil
+}
+
+// nextCursor builds the cursor pointing right after the last song of a page.
+func nextCursor(filter *models.Filter, last *models.Song) *models.Cursor {
+	ordering := filter.Ordering()
+	values := make([]string, 0, len(ordering))
+	for _, field := range ordering {
+		values = append(values, last.SortValue(field.Field))
+	}
+	return &models.Cursor{
+		ID:     last.ID,
+		Sort:   models.SortString(filter.Sort),
+		Values: values,
+	}
 }
 
 // ReadText retrieves a subset of song verses based on the start index and count.
-func (s *SongLibraryService) ReadVerse(id, start, count int) ([]*models.Verse, error) {
+// The song version is returned along with the verses, so callers can tag the response.
+// If the language query selects a translation, its verses are returned
+// together with the verses of the original text they are aligned with.
+func (s *SongLibraryService) ReadVerse(ctx context.Context, id, start, count int, lang *models.LanguageQuery) (*models.SongVerses, error) {
 	// Adjust negative count values to zero.
 	if count < 0 {
 		count = 0
 	}
+	// Verses are numbered from 1.
+	if start < 1 {
+		return nil, ErrVerseOutOfBound
+	}
 	// Convert the start index to zero-based indexing.
 	start--
 
 	s.log.Info("reading text of the song by id")
 
 	// Retrieve the song from the database by its ID.
-	song, err := s.SingStorage.ReadByID(id)
+	song, err := s.SingStorage.ReadByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
 	// Split the song's text into verses.
-	verses := strings.Split(song.Text, "\n\n")
+	verses := splitVerses(song.Text)
 	s.log.Info("retrieved verses", slog.Any("verses", verses))
 
 	// Check if the requested range of verses exceeds the available verses.
-	if start+count > len(verses) {
+	// The count is compared with the verses left, so that a huge count can't
+	// overflow start+count.
+	if start > len(verses) || count > len(verses)-start {
 		return nil, ErrVerseOutOfBound
 	}
 
+	// Pick the translation to read, if any.
+	translation, err := s.translation(ctx, song, lang)
+	if err != nil {
+		return nil, err
+	}
+
 	res := make([]*models.Verse, 0, count)
 
-	for _, verse := range verses[start : start+count] {
-		res = append(res, models.NewVerse(verse))
+	if translation == nil {
+		for _, verse := range verses[start : start+count] {
+			res = append(res, models.NewVerse(verse))
+		}
+		return &models.SongVerses{Version: song.Version, Verses: res}, nil
+	}
+
+	// The verses are aligned by number. A translation of an older version of
+	// the song may lack some verses, which are left empty.
+	translated := splitVerses(translation.Text)
+	for i, verse := range verses[start : start+count] {
+		res = append(res, &models.Verse{Original: verse})
+		if start+i < len(translated) {
+			res[i].Verse = translated[start+i]
+		}
 	}
 
 	// Return the requested range of verses as a string.
-	return res, nil
+	return &models.SongVerses{Version: song.Version, Verses: res, Translation: translation}, nil
+}
+
+// ReadTrash retrieves a page of songs that have been moved to the trash.
+func (s *SongLibraryService) ReadTrash(ctx context.Context, filter *models.Filter) (*models.SongPage, error) {
+	s.log.Info("reading trash")
+	filter.Deleted = true
+	return s.ReadFilteredSongs(ctx, filter)
+}
+
+// RestoreSong takes a song out of the trash and returns it.
+func (s *SongLibraryService) RestoreSong(ctx context.Context, id int) (*models.Song, error) {
+	s.log.Info("restoring song from trash")
+	if err := s.SingStorage.RestoreSong(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.SingStorage.ReadByID(ctx, id)
+}
+
+// RunTrashPurge permanently removes songs that have been in the trash for
+// longer than retention, checking every interval until ctx is cancelled.
+// The interval must be positive.
+func (s *SongLibraryService) RunTrashPurge(ctx context.Context, interval, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := s.SingStorage.PurgeDeletedSongs(ctx, time.Now().Add(-retention))
+		if err != nil {
+			s.log.Error("failed to purge trash", sl.Error(err))
+		} else if purged > 0 {
+			s.log.Info("purged songs from trash", slog.Int("count", purged))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
 }
 
 // UpdateSong updates the details of an existing song in the database.
-func (s *SongLibraryService) UpdateSong(song *models.Song) error {
+// The update only succeeds if the stored song still has song.Version.
+func (s *SongLibraryService) UpdateSong(ctx context.Context, song *models.Song) error {
 	s.log.Info("updating song information")
-	return s.SingStorage.UpdateSong(song)
+	return s.SingStorage.UpdateSong(ctx, song)
 }
 
-// DeleteSong deletes a song from the database by its ID.
-func (s *SongLibraryService) DeleteSong(id int) error {
+// DeleteSong moves a song to the trash by its ID. A non-zero version makes the
+// delete fail with database.ErrVersionMismatch if the song has been modified since.
+func (s *SongLibraryService) DeleteSong(ctx context.Context, id, version int) error {
 	s.log.Info("deleting song information")
-	return s.SingStorage.DeleteSong(id)
+	return s.SingStorage.DeleteSong(ctx, id, version)
 }
 
 // ReadByID retrieves all details about a song by its ID.
-func (s *SongLibraryService) ReadByID(id int) (*models.Song, error) {
+func (s *SongLibraryService) ReadByID(ctx context.Context, id int) (*models.Song, error) {
 	s.log.Info("retrieving song information by id")
-	return s.SingStorage.ReadByID(id)
+	return s.SingStorage.ReadByID(ctx, id)
+}
+
+// UpstreamStatus reports the health of the external API client.
+func (s *SongLibraryService) UpstreamStatus() []models.UpstreamStatus {
+	return s.ApiClient.Status()
+}
+
+// ReadGroups retrieves all groups together with the number of their songs.
+func (s *SongLibraryService) ReadGroups(ctx context.Context) ([]models.Group, error) {
+	s.log.Info("reading groups")
+	return s.SingStorage.ReadGroups(ctx)
+}
+
+// ReadGroupByID retrieves a group by its ID.
+func (s *SongLibraryService) ReadGroupByID(ctx context.Context, id int) (*models.Group, error) {
+	s.log.Info("retrieving group information by id")
+	return s.SingStorage.ReadGroupByID(ctx, id)
+}
+
+// CreateGroup creates a new group and returns its ID.
+func (s *SongLibraryService) CreateGroup(ctx context.Context, name string) (int, error) {
+	s.log.Info("creating group")
+	return s.SingStorage.CreateGroup(ctx, name)
+}
+
+// UpdateGroup renames an existing group.
+func (s *SongLibraryService) UpdateGroup(ctx context.Context, group *models.Group) error {
+	s.log.Info("updating group information")
+	return s.SingStorage.UpdateGroup(ctx, group)
+}
+
+// DeleteGroup deletes a group that has no songs left.
+func (s *SongLibraryService) DeleteGroup(ctx context.Context, id int) error {
+	s.log.Info("deleting group")
+	return s.SingStorage.DeleteGroup(ctx, id)
+}
+
+// ReadGroupSongs retrieves the songs of a group, applying the limit and offset of the filter.
+func (s *SongLibraryService) ReadGroupSongs(ctx context.Context, id int, filter *models.Filter) ([]models.Song, error) {
+	s.log.Info("reading songs of the group")
+
+	// Make sure the group exists, so an unknown group is reported as not found
+	// rather than as an empty list.
+	if _, err := s.SingStorage.ReadGroupByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	filter.GroupID = id
+	return s.SingStorage.ReadFilteredSongs(ctx, filter)
+}
+
+// ReadSongVersions retrieves the change history of a song, oldest version first.
+func (s *SongLibraryService) ReadSongVersions(ctx context.Context, id int) ([]models.SongVersion, error) {
+	s.log.Info("reading song versions")
+	return s.SingStorage.ReadSongVersions(ctx, id)
+}
+
+// ReadSongVersion retrieves a single version of a song.
+func (s *SongLibraryService) ReadSongVersion(ctx context.Context, id, version int) (*models.SongVersion, error) {
+	s.log.Info("reading song version")
+	return s.SingStorage.ReadSongVersion(ctx, id, version)
+}
+
+// RestoreSongVersion brings a song back to the state of one of its versions.
+func (s *SongLibraryService) RestoreSongVersion(ctx context.Context, id, version int) (*models.Song, error) {
+	s.log.Info("restoring song version", slog.Int("id", id), slog.Int("version", version))
+	return s.SingStorage.RestoreSongVersion(ctx, id, version)
 }
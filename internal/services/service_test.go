@@ -0,0 +1,269 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/notblinkyet/song-library-api/internal/database/memory"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// testText has three verses, the second of them labelled.
+const testText = "Ooh baby, don't you know I suffer?\nOoh baby, can you hear me moan?\n\n" +
+	"[Chorus]\nOoh\nYou set my soul alight\n\nGlaciers melting in the dead of night"
+
+// newTestService returns a service on in-memory storage holding a song with
+// testText, and the ID of the song.
+func newTestService(t *testing.T) (*SongLibraryService, int) {
+	t.Helper()
+	db := memory.NewMemory()
+	t.Cleanup(db.Close)
+	id, err := db.CreateSong(context.Background(), &models.Song{Title: "Supermassive Black Hole", Group: "Muse", Text: testText})
+	if err != nil {
+		t.Fatalf("failed to create song: %v", err)
+	}
+	return NewSongLibraryService(db, nil, slog.New(slog.NewTextHandler(io.Discard, nil))), id
+}
+
+func TestReadVerse(t *testing.T) {
+	s, id := newTestService(t)
+
+	tests := []struct {
+		name    string
+		start   int
+		count   int
+		want    []string
+		wantErr error
+	}{
+		{name: "first verse", start: 1, count: 1, want: []string{"Ooh baby, don't you know I suffer?\nOoh baby, can you hear me moan?"}},
+		{name: "last verses", start: 2, count: 2, want: []string{"[Chorus]\nOoh\nYou set my soul alight", "Glaciers melting in the dead of night"}},
+		{name: "no verses at the end", start: 4, count: 0, want: []string{}},
+		{name: "negative count", start: 1, count: -1, want: []string{}},
+		{name: "too many verses", start: 2, count: 3, wantErr: ErrVerseOutOfBound},
+		{name: "start after the end", start: 5, count: 0, wantErr: ErrVerseOutOfBound},
+		{name: "start before the first verse", start: 0, count: 1, wantErr: ErrVerseOutOfBound},
+		{name: "huge count", start: 2, count: math.MaxInt, wantErr: ErrVerseOutOfBound},
+		{name: "huge start", start: math.MaxInt, count: math.MaxInt, wantErr: ErrVerseOutOfBound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.ReadVerse(context.Background(), id, tt.start, tt.count, nil)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			verses := make([]string, len(got.Verses))
+			for i, verse := range got.Verses {
+				verses[i] = verse.Verse
+			}
+			if !reflect.DeepEqual(verses, tt.want) {
+				t.Errorf("verses = %q, want %q", verses, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadLyrics(t *testing.T) {
+	s, id := newTestService(t)
+
+	tests := []struct {
+		name      string
+		query     models.LyricsQuery
+		wantLines []int
+		wantErr   error
+	}{
+		{name: "whole song", wantLines: []int{1, 2, 3, 4, 5}},
+		{name: "verse range", query: models.LyricsQuery{Verses: []models.Range{{From: 2, To: 3}}}, wantLines: []int{3, 4, 5}},
+		{name: "verse range past the end", query: models.LyricsQuery{Verses: []models.Range{{From: 3, To: 100}}}, wantLines: []int{5}},
+		{name: "open verse range", query: models.LyricsQuery{Verses: []models.Range{{From: 2}}}, wantLines: []int{3, 4, 5}},
+		{name: "line range", query: models.LyricsQuery{Lines: []models.Range{{From: 2, To: 3}}}, wantLines: []int{2, 3}},
+		{name: "last line", query: models.LyricsQuery{Lines: []models.Range{{From: 5, To: math.MaxInt}}}, wantLines: []int{5}},
+		{name: "section", query: models.LyricsQuery{Section: models.SectionChorus}, wantLines: []int{3, 4}},
+		{
+			name:      "verses and lines",
+			query:     models.LyricsQuery{Verses: []models.Range{{From: 1, To: 2}}, Lines: []models.Range{{From: 2, To: 4}}},
+			wantLines: []int{2, 3, 4},
+		},
+		{name: "verse after the end", query: models.LyricsQuery{Verses: []models.Range{{From: 4}}}, wantErr: ErrVerseOutOfBound},
+		{name: "line after the end", query: models.LyricsQuery{Lines: []models.Range{{From: 6, To: 7}}}, wantErr: ErrLineOutOfBound},
+		{
+			name:    "huge verse",
+			query:   models.LyricsQuery{Verses: []models.Range{{From: math.MaxInt, To: math.MaxInt}}},
+			wantErr: ErrVerseOutOfBound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.ReadLyrics(context.Background(), id, &tt.query)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.TotalVerses != 3 || got.TotalLines != 5 {
+				t.Errorf("totals = %d verses, %d lines, want 3 and 5", got.TotalVerses, got.TotalLines)
+			}
+			lines := []int{}
+			for _, verse := range got.Verses {
+				for _, line := range verse.Lines {
+					lines = append(lines, line.Number)
+				}
+			}
+			if !reflect.DeepEqual(lines, tt.wantLines) {
+				t.Errorf("lines = %v, want %v", lines, tt.wantLines)
+			}
+		})
+	}
+}
+
+func TestSaveTranslationAlignment(t *testing.T) {
+	s, id := newTestService(t)
+
+	tests := []struct {
+		name    string
+		text    string
+		wantErr error
+	}{
+		{name: "aligned", text: "Ooh baby\n\n[Refrain]\nOoh\n\nGletscher"},
+		{name: "fewer verses", text: "Ooh baby\n\nOoh", wantErr: ErrTranslationMisaligned},
+		{name: "more verses", text: "One\n\nTwo\n\nThree\n\nFour", wantErr: ErrTranslationMisaligned},
+		{name: "label splitting a verse", text: "Ooh baby\n[Refrain]\nOoh\n\nLa\n\nGletscher", wantErr: ErrTranslationMisaligned},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := s.SaveTranslation(context.Background(), id, "de", tt.text)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// translatedText is testText translated into English, aligned with it.
+const translatedText = "Ooh baby\n\n[Chorus]\nOoh\n\nGlaciers"
+
+func TestTranslationNegotiation(t *testing.T) {
+	s, id := newTestService(t)
+	ctx := context.Background()
+	if _, _, err := s.SaveTranslation(ctx, id, "en", translatedText); err != nil {
+		t.Fatalf("failed to save translation: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		origLang string
+		query    models.LanguageQuery
+		wantLang string
+		wantErr  error
+	}{
+		{name: "no preference", origLang: "ru"},
+		{name: "translation", origLang: "ru", query: models.LanguageQuery{AcceptLanguage: "en"}, wantLang: "en"},
+		{name: "original preferred", origLang: "ru", query: models.LanguageQuery{AcceptLanguage: "ru-RU,ru;q=0.9,en;q=0.8"}},
+		{name: "translation preferred", origLang: "ru", query: models.LanguageQuery{AcceptLanguage: "en,ru;q=0.9"}, wantLang: "en"},
+		{name: "original language unknown", query: models.LanguageQuery{AcceptLanguage: "ru-RU,ru;q=0.9,en;q=0.8"}, wantLang: "en"},
+		{name: "original requested", origLang: "ru", query: models.LanguageQuery{Lang: "ru"}},
+		{name: "translation requested", origLang: "ru", query: models.LanguageQuery{Lang: "en", AcceptLanguage: "ru"}, wantLang: "en"},
+		{name: "missing translation", origLang: "ru", query: models.LanguageQuery{Lang: "de"}, wantErr: memory.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			song, err := s.ReadByID(ctx, id)
+			if err != nil {
+				t.Fatalf("failed to read song: %v", err)
+			}
+			song.Lang = tt.origLang
+			song.Version = 0
+			if err = s.UpdateSong(ctx, song); err != nil {
+				t.Fatalf("failed to update song: %v", err)
+			}
+
+			got, err := s.ReadLyrics(ctx, id, &models.LyricsQuery{Language: tt.query})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Lang != tt.wantLang {
+				t.Errorf("lang = %q, want %q", got.Lang, tt.wantLang)
+			}
+		})
+	}
+}
+
+func TestTranslationOutdated(t *testing.T) {
+	s, id := newTestService(t)
+	ctx := context.Background()
+	if _, _, err := s.SaveTranslation(ctx, id, "en", translatedText); err != nil {
+		t.Fatalf("failed to save translation: %v", err)
+	}
+
+	steps := []struct {
+		name         string
+		change       func() error
+		wantOutdated bool
+	}{
+		{
+			name: "moved to the trash and restored",
+			change: func() error {
+				if err := s.DeleteSong(ctx, id, 0); err != nil {
+					return err
+				}
+				_, err := s.RestoreSong(ctx, id)
+				return err
+			},
+		},
+		{
+			name: "link changed",
+			change: func() error {
+				song, err := s.ReadByID(ctx, id)
+				if err != nil {
+					return err
+				}
+				song.Link = "https://example.com"
+				return s.UpdateSong(ctx, song)
+			},
+		},
+		{
+			name: "text changed",
+			change: func() error {
+				song, err := s.ReadByID(ctx, id)
+				if err != nil {
+					return err
+				}
+				song.Text += "!"
+				return s.UpdateSong(ctx, song)
+			},
+			wantOutdated: true,
+		},
+	}
+	for _, step := range steps {
+		if err := step.change(); err != nil {
+			t.Fatalf("%s: unexpected error: %v", step.name, err)
+		}
+		translation, err := s.ReadTranslation(ctx, id, "en")
+		if err != nil {
+			t.Fatalf("%s: failed to read translation: %v", step.name, err)
+		}
+		if translation.Outdated != step.wantOutdated {
+			t.Errorf("%s: outdated = %v, want %v", step.name, translation.Outdated, step.wantOutdated)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/notblinkyet/song-library-api/internal/lib/synced"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// ReadSyncedLyrics returns the lyrics of the song synchronised with its
+// playback.
+func (s *SongLibraryService) ReadSyncedLyrics(ctx context.Context, id int) (*models.SyncedLyrics, error) {
+	s.log.Info("reading synced lyrics", slog.Int("id", id))
+	return s.SingStorage.ReadSyncedLyrics(ctx, id)
+}
+
+// SaveSyncedLyrics validates the lines and attaches them to the song,
+// replacing the previous ones.
+func (s *SongLibraryService) SaveSyncedLyrics(ctx context.Context, id int, lines []models.SyncedLine) (*models.SyncedLyrics, error) {
+	s.log.Info("saving synced lyrics", slog.Int("id", id), slog.Int("lines", len(lines)))
+
+	if err := synced.Validate(lines); err != nil {
+		return nil, err
+	}
+	lyrics := &models.SyncedLyrics{SongID: id, Lines: lines}
+	if err := s.SingStorage.SaveSyncedLyrics(ctx, lyrics); err != nil {
+		return nil, err
+	}
+	return lyrics, nil
+}
+
+// DeleteSyncedLyrics removes the synced lyrics of the song.
+func (s *SongLibraryService) DeleteSyncedLyrics(ctx context.Context, id int) error {
+	s.log.Info("deleting synced lyrics", slog.Int("id", id))
+	return s.SingStorage.DeleteSyncedLyrics(ctx, id)
+}
+
+// LyricsAt returns the line of the song sung at the playback position t in
+// seconds and the line after it.
+func (s *SongLibraryService) LyricsAt(ctx context.Context, id int, t float64) (*models.LyricsPosition, error) {
+	lyrics, err := s.SingStorage.ReadSyncedLyrics(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	current, next := synced.At(lyrics.Lines, t)
+	return &models.LyricsPosition{SongID: id, Time: t, Current: current, Next: next}, nil
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/notblinkyet/song-library-api/internal/lib/langtag"
+	"github.com/notblinkyet/song-library-api/internal/lib/lyrics"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// ReadTranslations returns the translations of the song ordered by language.
+func (s *SongLibraryService) ReadTranslations(ctx context.Context, id int) ([]models.Translation, error) {
+	s.log.Info("reading translations", slog.Int("id", id))
+	return s.SingStorage.ReadTranslations(ctx, id)
+}
+
+// ReadTranslation returns the translation of the song into the language with
+// the canonical tag lang.
+func (s *SongLibraryService) ReadTranslation(ctx context.Context, id int, lang string) (*models.Translation, error) {
+	s.log.Info("reading translation", slog.Int("id", id), slog.String("lang", lang))
+	return s.SingStorage.ReadTranslation(ctx, id, lang)
+}
+
+// SaveTranslation creates or replaces the translation of the song into the
+// language with the canonical tag lang. The translation must have as many
+// verses as the text of the song, both separated by blank lines as ReadVerse
+// reads them and split into sections as ReadLyrics reads them, so that they
+// can be shown side by side. created reports whether the song had no
+// translation into the language.
+func (s *SongLibraryService) SaveTranslation(ctx context.Context, id int, lang, text string) (translation *models.Translation, created bool, err error) {
+	s.log.Info("saving translation", slog.Int("id", id), slog.String("lang", lang))
+
+	song, err := s.SingStorage.ReadByID(ctx, id)
+	if err != nil {
+		return nil, false, err
+	}
+	if want, got := len(splitVerses(song.Text)), len(splitVerses(text)); want != got {
+		return nil, false, fmt.Errorf("%w: the translation has %d verses, the song has %d", ErrTranslationMisaligned, got, want)
+	}
+	sections, err := s.sections(ctx, song)
+	if err != nil {
+		return nil, false, err
+	}
+	if want, got := len(sections.Sections), len(lyrics.Parse(text)); want != got {
+		return nil, false, fmt.Errorf("%w: the translation has %d sections, the song has %d", ErrTranslationMisaligned, got, want)
+	}
+
+	translation = &models.Translation{SongID: id, Lang: lang, Text: text, SongVersion: song.Version}
+	created, err = s.SingStorage.SaveTranslation(ctx, translation)
+	if err != nil {
+		return nil, false, err
+	}
+	return translation, created, nil
+}
+
+// DeleteTranslation removes the translation of the song into the language
+// with the canonical tag lang.
+func (s *SongLibraryService) DeleteTranslation(ctx context.Context, id int, lang string) error {
+	s.log.Info("deleting translation", slog.Int("id", id), slog.String("lang", lang))
+	return s.SingStorage.DeleteTranslation(ctx, id, lang)
+}
+
+// translation returns the translation of the song the query asks for, or nil
+// if the original text should be used. A translation requested with Lang
+// must exist, while Accept-Language falls back to the original text. The
+// language of the original text, if known, is negotiated like the languages
+// of the translations, so that it wins over the translations it is preferred to.
+func (s *SongLibraryService) translation(ctx context.Context, song *models.Song, query *models.LanguageQuery) (*models.Translation, error) {
+	if query == nil {
+		return nil, nil
+	}
+	if query.Lang != "" {
+		if query.Lang == song.Lang {
+			return nil, nil
+		}
+		return s.SingStorage.ReadTranslation(ctx, song.ID, query.Lang)
+	}
+	if query.AcceptLanguage == "" {
+		return nil, nil
+	}
+
+	translations, err := s.SingStorage.ReadTranslations(ctx, song.ID)
+	if err != nil {
+		return nil, err
+	}
+	langs := make([]string, 0, len(translations)+1)
+	if song.Lang != "" {
+		langs = append(langs, song.Lang)
+	}
+	for _, translation := range translations {
+		langs = append(langs, translation.Lang)
+	}
+	lang, ok := langtag.Match(query.AcceptLanguage, langs)
+	if !ok || lang == song.Lang {
+		return nil, nil
+	}
+	for i := range translations {
+		if translations[i].Lang == lang {
+			s.log.Debug("negotiated translation", slog.Int("id", song.ID), slog.String("lang", lang))
+			return &translations[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// splitVerses splits a text into verses separated by blank lines, the way
+// ReadVerse does.
+func splitVerses(text string) []string {
+	return strings.Split(text, "\n\n")
+}
@@ -0,0 +1,71 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/notblinkyet/song-library-api/internal/database"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+	"github.com/notblinkyet/song-library-api/internal/lib/validation"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// @Summary Create a new group
+// @Description Creates a new group in the library. Group names are unique.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param group body models.GroupRequest true "Group details"
+// @Success 201 {object} models.Id "Successfully created group"
+// @Failure 400 {object} models.Problem "Invalid request (e.g., missing name)"
+// @Failure 409 {object} models.Problem "Group with this name already exists"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Router /groups [post]
+func (h *Handler) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to create a new group")
+
+	// Parse and decode the request body into the GroupRequest model.
+	var req models.GroupRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		h.log.Error("failed to decode request body", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "invalid request body")
+		return
+	}
+
+	// Validate required fields in the request.
+	req.Name = strings.TrimSpace(req.Name)
+	var v validation.Validator
+	validation.GroupRequest(&v, &req)
+	if err = v.Err(); err != nil {
+		h.log.Warn("invalid group", sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+
+	// Call the service layer to create the group.
+	id, err := h.service.CreateGroup(r.Context(), req.Name)
+	if err != nil {
+		if errors.Is(err, database.ErrAlreadyExists) {
+			h.log.Warn("group already exists", slog.String("name", req.Name))
+		} else {
+			h.log.Error("failed to create group", sl.Error(err))
+		}
+		writeError(w, r, err)
+		return
+	}
+	h.log.Info("group created successfully", slog.Int("groupID", id))
+
+	// Return the ID of the newly created group in the response.
+	w.WriteHeader(http.StatusCreated)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent(" ", "\t")
+	err = encoder.Encode(models.NewId(id))
+	if err != nil {
+		h.log.Error("failed to encode group ID", sl.Error(err))
+		return
+	}
+}
@@ -3,37 +3,51 @@ package http
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
 
 	"github.com/notblinkyet/song-library-api/internal/lib/api"
 	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+	"github.com/notblinkyet/song-library-api/internal/lib/validation"
 	"github.com/notblinkyet/song-library-api/internal/models"
 )
 
 // Handler provides HTTP handlers for song-related operations.
 type Handler struct {
-	service SongLibraryService
-	log     *slog.Logger
+	service     SongLibraryService
+	log         *slog.Logger
+	asyncCreate bool // Create songs with background enrichment unless asked to wait.
 }
 
-// NewHandler initializes and returns a new Handler instance.
-func NewHandler(service SongLibraryService, log *slog.Logger) *Handler {
+// NewHandler initializes and returns a new Handler instance. With asyncCreate
+// POST /songs doesn't wait for the song info API unless asked to, without it
+// songs are always created synchronously, as no enrichment workers are running.
+func NewHandler(service SongLibraryService, log *slog.Logger, asyncCreate bool) *Handler {
 	return &Handler{
-		service: service,
-		log:     log,
+		service:     service,
+		log:         log,
+		asyncCreate: asyncCreate,
 	}
 }
 
 // @Summary Create a new song
 // @Description Creates a new song in the library. Requires a valid JSON request body containing the song's details.
+// @Description The details of the song are fetched from the song info API. When the server runs in async mode, the song is stored right away
+// @Description with the pending status and 202 is returned with the ID of the job that fetches the details.
+// @Description "Prefer: respond-sync" makes the request wait for the API. In sync mode "Prefer: respond-async" is ignored.
 // @Tags songs
 // @Accept json
 // @Produce json
 // @Param song body models.CreateSongRequest true "Song details"
+// @Param Prefer header string false "respond-sync to wait for the song info API in async mode"
 // @Success 201 {object} models.Id "Successfully created song"
-// @Failure 400 {object} string "Invalid request (e.g., missing required fields)"
-// @Failure 500 {object} string "Internal server error"
+// @Success 202 {object} models.AcceptedSong "Song stored, its details are being fetched"
+// @Header 202 {string} Location "URL of the job"
+// @Failure 400 {object} models.Problem "Invalid request (e.g., missing required fields) or the song info API rejected the song"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Failure 502 {object} models.Problem "Song info API failed"
 // @Router /songs [post]
 func (h *Handler) CreateSong(w http.ResponseWriter, r *http.Request) {
 	h.log.Info("received request to create a new song")
@@ -43,29 +57,35 @@ func (h *Handler) CreateSong(w http.ResponseWriter, r *http.Request) {
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		h.log.Error("failed to decode request body", sl.Error(err))
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "invalid request body")
 		return
 	}
 	h.log.Debug("decoded request body", slog.Any("request", req))
 
-	// Validate required fields in the request.
-	if req.Group == "" || req.Title == "" {
-		h.log.Warn("missing required fields: group or title")
-		http.Error(w, "Group and title are required fields", http.StatusBadRequest)
+	// Validate the fields of the request.
+	var v validation.Validator
+	validation.CreateSongRequest(&v, &req)
+	if err = v.Err(); err != nil {
+		h.log.Warn("invalid song", sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+
+	if h.preferAsync(r) {
+		h.createSongAsync(w, r, &req)
 		return
 	}
 
 	// Call the service layer to create the song and retrieve the new song's ID.
-	id, err := h.service.Create(&req)
+	id, err := h.service.Create(r.Context(), &req)
 	if err != nil {
 		// Handle specific errors returned by the service.
 		if errors.Is(err, api.ErrInternalServer) {
 			h.log.Error("internal server error during song creation", sl.Error(err))
-			http.Error(w, "internal server error during song creation", http.StatusInternalServerError)
 		} else {
 			h.log.Error("failed to create song", sl.Error(err))
-			http.Error(w, "failed to create song", http.StatusBadRequest)
 		}
+		writeError(w, r, err)
 		return
 	}
 	h.log.Info("song created successfully", slog.Int("songID", id))
@@ -80,3 +100,43 @@ func (h *Handler) CreateSong(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// createSongAsync stores the song and leaves fetching its details to the
+// enrichment workers.
+func (h *Handler) createSongAsync(w http.ResponseWriter, r *http.Request, req *models.CreateSongRequest) {
+	job, err := h.service.CreateAsync(r.Context(), req)
+	if err != nil {
+		h.log.Error("failed to create pending song", sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+	h.log.Info("pending song created", slog.Int("songID", job.SongID), slog.Int("jobID", job.ID))
+
+	// Point the client at the job.
+	w.Header().Set("Location", fmt.Sprintf("/jobs/%d", job.ID))
+	w.Header().Set("Preference-Applied", "respond-async")
+	w.WriteHeader(http.StatusAccepted)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent(" ", "\t")
+	err = encoder.Encode(&models.AcceptedSong{Id: job.SongID, JobID: job.ID, Status: models.SongStatusPending})
+	if err != nil {
+		h.log.Error("failed to encode accepted song", sl.Error(err))
+		return
+	}
+}
+
+// preferAsync reports whether the song should be created without waiting for
+// the song info API. In async mode the Prefer header can ask to wait for it.
+func (h *Handler) preferAsync(r *http.Request) bool {
+	if !h.asyncCreate {
+		return false
+	}
+	for _, header := range r.Header.Values("Prefer") {
+		for _, pref := range strings.Split(header, ",") {
+			if strings.ToLower(strings.TrimSpace(pref)) == "respond-sync" {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+)
+
+// @Summary Delete the chord chart of a song
+// @Description Removes the ChordPro chord chart from a song. The song itself is kept.
+// @Tags chords
+// @Produce json
+// @Param id path int true "Song ID"
+// @Success 204 "Chord chart deleted"
+// @Failure 400 {object} models.Problem "Invalid song ID"
+// @Failure 404 {object} models.Problem "Song not found or has no chord chart"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Router /songs/{id}/chords [delete]
+func (h *Handler) DeleteChordChart(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to delete chord chart")
+
+	// Parse the song ID from the URL parameter.
+	idString := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		h.log.Error("failed to parse song ID", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid song ID")
+		return
+	}
+
+	// Call the service layer to delete the chart.
+	err = h.service.DeleteChordChart(r.Context(), id)
+	if err != nil {
+		h.log.Error("failed to delete chord chart", slog.Int("id", id), sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+	h.log.Info("chord chart deleted successfully", slog.Int("id", id))
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+)
+
+// @Summary Delete a group by ID
+// @Description Deletes a group from the library. Only groups without songs can be deleted.
+// @Tags groups
+// @Produce json
+// @Param id path int true "Group ID"
+// @Success 200 {object} nil
+// @Failure 400 {object} models.Problem "Invalid group ID"
+// @Failure 404 {object} models.Problem "Group not found"
+// @Failure 409 {object} models.Problem "Group still has songs"
+// @Failure 500 {object} models.Problem "Internal server error during deletion"
+// @Router /groups/{id} [delete]
+func (h *Handler) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to delete a group")
+
+	// Parse the group ID from the URL parameter.
+	idString := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		h.log.Error("failed to parse group ID", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid group ID")
+		return
+	}
+
+	// Call the service layer to delete the group by ID.
+	err = h.service.DeleteGroup(r.Context(), id)
+	if err != nil {
+		h.log.Error("failed to delete group", slog.Int("id", id), sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+	h.log.Info("group deleted successfully", slog.Int("id", id))
+	w.WriteHeader(http.StatusOK)
+}
@@ -1,29 +1,27 @@
 package http
 
 import (
-	"errors"
 	"log/slog"
 	"net/http"
 	"strconv"
 
 	"github.com/go-chi/chi"
-	"github.com/notblinkyet/song-library-api/internal/database/postgresql"
+	"github.com/notblinkyet/song-library-api/internal/database"
 	"github.com/notblinkyet/song-library-api/internal/lib/sl"
 )
 
-var (
-	ErrFailToDeleteSong = errors.New("fail to delete")
-)
-
 // @Summary Delete a song by ID
-// @Description Deletes a song from the library by its ID.
+// @Description Moves a song to the trash by its ID. It can be restored with POST /songs/{id}/restore until it is purged.
 // @Tags songs
 // @Accept json
 // @Produce json
 // @Param id path int true "Song ID"
+// @Param If-Match header string false "ETag of the song version that is expected to be deleted"
 // @Success 200 {object} nil
-// @Failure 400 {object} string "Invalid song ID"
-// @Failure 500 {object} string "Internal server error during deletion"
+// @Failure 400 {object} models.Problem "Invalid song ID"
+// @Failure 404 {object} models.Problem "Song not found"
+// @Failure 412 {object} models.Problem "Song has been modified since the version in If-Match"
+// @Failure 500 {object} models.Problem "Internal server error during deletion"
 // @Router /songs/{id} [delete]
 func (h *Handler) DeleteSong(w http.ResponseWriter, r *http.Request) {
 	h.log.Info("received request to delete a song")
@@ -33,19 +31,33 @@ func (h *Handler) DeleteSong(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(idString)
 	if err != nil {
 		h.log.Error("failed to parse song ID", sl.Error(err))
-		http.Error(w, "Invalid song ID", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid song ID")
 		return
 	}
 
+	// With If-Match, only delete the song if it still has one of the listed
+	// versions. The version is passed on, so the check is atomic with the delete.
+	version := 0
+	if match := r.Header.Get("If-Match"); match != "" && match != "*" {
+		song, err := h.service.ReadByID(r.Context(), id)
+		if err != nil {
+			h.log.Error("failed to retrieve song", slog.Int("id", id), sl.Error(err))
+			writeError(w, r, err)
+			return
+		}
+		if !matchETag(match, song.Version, false) {
+			h.log.Warn("song version doesn't match If-Match", slog.Int("id", id), slog.Int("version", song.Version))
+			writeError(w, r, database.ErrVersionMismatch)
+			return
+		}
+		version = song.Version
+	}
+
 	// Call the service layer to delete the song by ID.
-	err = h.service.DeleteSong(id)
+	err = h.service.DeleteSong(r.Context(), id, version)
 	if err != nil {
 		h.log.Error("failed to delete song", slog.Int("id", id), sl.Error(err))
-		if errors.Is(err, postgresql.ErrNotFound) {
-			http.Error(w, postgresql.ErrNotFound.Error(), http.StatusNotFound)
-			return
-		}
-		http.Error(w, "failed to delete song", http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 	h.log.Info("song deleted successfully", slog.Int("id", id))
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+)
+
+// @Summary Delete the synced lyrics of a song
+// @Description Removes the lyrics synchronised with playback from a song. The song itself is kept.
+// @Tags synced lyrics
+// @Produce json
+// @Param id path int true "Song ID"
+// @Success 204 "Synced lyrics deleted"
+// @Failure 400 {object} models.Problem "Invalid song ID"
+// @Failure 404 {object} models.Problem "Song not found or has no synced lyrics"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Router /songs/{id}/lyrics/synced [delete]
+func (h *Handler) DeleteSyncedLyrics(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to delete synced lyrics")
+
+	// Parse the song ID from the URL parameter.
+	idString := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		h.log.Error("failed to parse song ID", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid song ID")
+		return
+	}
+
+	// Call the service layer to delete the lyrics.
+	err = h.service.DeleteSyncedLyrics(r.Context(), id)
+	if err != nil {
+		h.log.Error("failed to delete synced lyrics", slog.Int("id", id), sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+	h.log.Info("synced lyrics deleted successfully", slog.Int("id", id))
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/notblinkyet/song-library-api/internal/lib/langtag"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+)
+
+// @Summary Delete a translation of a song
+// @Description Removes the translation of a song into a language. The song and its other translations are kept.
+// @Tags translations
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param lang path string true "BCP 47 language tag, e.g. en or pt-BR"
+// @Success 204 "Translation deleted"
+// @Failure 400 {object} models.Problem "Invalid song ID or language tag"
+// @Failure 404 {object} models.Problem "Song or translation not found"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Router /songs/{id}/translations/{lang} [delete]
+func (h *Handler) DeleteTranslation(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to delete translation")
+
+	// Parse the song ID and the language from the URL parameters.
+	idString := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		h.log.Error("failed to parse song ID", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid song ID")
+		return
+	}
+	lang, err := langtag.Canonical(chi.URLParam(r, "lang"))
+	if err != nil {
+		h.log.Warn("failed to parse language tag", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid language tag")
+		return
+	}
+
+	// Call the service layer to delete the translation.
+	err = h.service.DeleteTranslation(r.Context(), id, lang)
+	if err != nil {
+		h.log.Error("failed to delete translation", slog.Int("id", id), slog.String("lang", lang), sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+	h.log.Info("translation deleted successfully", slog.Int("id", id), slog.String("lang", lang))
+	w.WriteHeader(http.StatusNoContent)
+}
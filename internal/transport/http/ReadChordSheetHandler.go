@@ -0,0 +1,118 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi"
+	parseurl "github.com/notblinkyet/song-library-api/internal/lib/ParseURL"
+	"github.com/notblinkyet/song-library-api/internal/lib/chordpro"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+	"github.com/notblinkyet/song-library-api/internal/lib/validation"
+)
+
+// @Summary Retrieve the chord sheet of a song
+// @Description Retrieves the chord chart of a song parsed into sections and lines (json), as a ChordPro document (chordpro), as plain text with chords above the lyrics (text) or as an HTML page (html).
+// @Description transpose moves every chord, including slash chords and the key, by that many semitones; the new key decides between sharps and flats. capo rewrites the chords for playing with the capo on that fret instead of the one of the chart, keeping the sound.
+// @Tags chords
+// @Produce json
+// @Produce application/x-chordpro
+// @Produce plain
+// @Produce html
+// @Param id path int true "Song ID"
+// @Param format query string false "Format of the sheet" Enums(json, chordpro, text, html) default(json)
+// @Param transpose query int false "Semitones to move the chords by, from -11 to 11, e.g. +2" default(0)
+// @Param capo query int false "Fret of the capo, from 0 to 11"
+// @Success 200 {object} models.ChordSheet "Chord sheet"
+// @Failure 400 {object} models.Problem "Invalid song ID or query parameters"
+// @Failure 404 {object} models.Problem "Song not found or has no chord chart"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Router /songs/{id}/chords [get]
+func (h *Handler) ReadChordSheet(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to read chord sheet")
+
+	// Parse the song ID from the URL parameter.
+	idString := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		h.log.Error("failed to parse song ID", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid song ID")
+		return
+	}
+
+	// Parse the query parameters. An unescaped + in transpose=+2 arrives as
+	// a space, so spaces are trimmed.
+	var v validation.Validator
+	query := r.URL.Query()
+	format := parseurl.ParseString(query, "format", chordpro.FormatJSON)
+	v.Check(format == chordpro.FormatJSON || format == chordpro.FormatChordPro ||
+		format == chordpro.FormatText || format == chordpro.FormatHTML,
+		"format", "must be one of json, chordpro, text, html")
+	query.Set("transpose", strings.TrimSpace(query.Get("transpose")))
+	transpose, err := parseurl.ParseInt(query, "transpose", 0)
+	if err != nil {
+		v.AddError("transpose", err)
+	} else {
+		v.Check(transpose >= -11 && transpose <= 11, "transpose", "must be between -11 and 11")
+	}
+	var capo *int
+	if query.Get("capo") != "" {
+		fret, err := parseurl.ParseInt(query, "capo", 0)
+		if err != nil {
+			v.AddError("capo", err)
+		} else {
+			v.Check(fret >= 0 && fret <= 11, "capo", "must be between 0 and 11")
+		}
+		capo = &fret
+	}
+	if err = v.Err(); err != nil {
+		h.log.Warn("invalid query parameters", sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+
+	// Call the service layer to retrieve the sheet.
+	sheet, err := h.service.ReadChordSheet(r.Context(), id, transpose, capo)
+	if err != nil {
+		h.log.Error("failed to retrieve chord sheet", slog.Int("id", id), sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+	h.log.Info("chord sheet retrieved successfully", slog.Int("id", id), slog.String("format", format))
+
+	// Return the sheet in the requested format.
+	switch format {
+	case chordpro.FormatChordPro:
+		w.Header().Set("Content-Type", mediaTypeChordPro+"; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, err = io.WriteString(w, chordpro.Encode(sheet))
+	case chordpro.FormatText:
+		w.Header().Set("Content-Type", mediaTypePlainText+"; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, err = io.WriteString(w, chordpro.Text(sheet))
+	case chordpro.FormatHTML:
+		var page string
+		page, err = chordpro.HTML(sheet)
+		if err != nil {
+			h.log.Error("failed to render chord sheet", sl.Error(err))
+			writeError(w, r, err)
+			return
+		}
+		w.Header().Set("Content-Type", mediaTypeHTML+"; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, err = io.WriteString(w, page)
+	default:
+		w.WriteHeader(http.StatusOK)
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent(" ", "\t")
+		err = encoder.Encode(sheet)
+	}
+	if err != nil {
+		h.log.Error("failed to write chord sheet", sl.Error(err))
+		return
+	}
+}
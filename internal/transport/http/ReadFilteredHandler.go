@@ -2,62 +2,123 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"time"
 
 	parseurl "github.com/notblinkyet/song-library-api/internal/lib/ParseURL"
+	"github.com/notblinkyet/song-library-api/internal/lib/cursor"
 	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+	"github.com/notblinkyet/song-library-api/internal/lib/validation"
 	"github.com/notblinkyet/song-library-api/internal/models"
+	"github.com/notblinkyet/song-library-api/internal/services"
 )
 
 // @Summary Retrieve songs based on filters
-// @Description Retrieves a list of songs from the library based on optional filters.
+// @Description Retrieves a page of songs from the library based on optional filters.
+// @Description Pages can be requested either by offset or by the opaque cursor returned in next_cursor.
 // @Tags songs
 // @Accept json
 // @Produce json
-// @Param song query string false "Song title"
-// @Param group query string false "Song group"
-// @Param release_date query string false "Song release date YYYY.MM.DD"
-// @Param text query string false "Text search in song details"
+// @Param song query []string false "Song title; repeat the parameter to match any of several titles" collectionFormat(multi)
+// @Param song_match query string false "How song is matched: exact (default), prefix or contains; prefix and contains ignore case" Enums(exact, prefix, contains)
+// @Param group query []string false "Song group; repeat the parameter to match any of several groups" collectionFormat(multi)
+// @Param group_match query string false "How group is matched: exact (default), prefix or contains; prefix and contains ignore case" Enums(exact, prefix, contains)
+// @Param release_date query string false "Song release date DD.MM.YYYY"
+// @Param release_date_from query string false "Earliest release date DD.MM.YYYY, inclusive"
+// @Param release_date_to query string false "Latest release date DD.MM.YYYY, inclusive"
+// @Param year query int false "Release year"
+// @Param text query string false "Case-insensitive substring search in song text"
+// @Param q query string false "Full-text search in song titles and lyrics (websearch syntax, Russian and English). Results are ordered by relevance and include rank and snippet."
 // @Param link query string false "Link search in song details"
-// @Success 200 {array} models.Song "Successfully retrieved songs"
-// @Failure 400 {object} string "Invalid request (e.g., invalid filter parameters)"
-// @Failure 500 {object} string "Internal server error"
+// @Param limit query int false "Maximum number of songs on the page"
+// @Param offset query int false "Number of songs to skip (offset pagination)"
+// @Param sort query string false "Comma-separated sort keys: id, title (or song), group, release_date; prefix a key with - for descending order, e.g. -release_date,title"
+// @Param cursor query string false "Cursor from next_cursor of the previous page (cursor pagination)"
+// @Param total query bool false "Include the total number of matching songs"
+// @Success 200 {object} models.SongPage "Successfully retrieved songs"
+// @Header 200 {string} Link "RFC 8288 links to the first, next and previous pages"
+// @Failure 400 {object} models.Problem "Invalid request (e.g., invalid filter parameters)"
+// @Failure 500 {object} models.Problem "Internal server error"
 // @Router /songs [get]
 func (h *Handler) ReadFilteredSongs(w http.ResponseWriter, r *http.Request) {
 	h.log.Info("received request to read filtered songs")
 
-	// Extract filtering parameters from the query string.
+	// Extract filtering parameters from the query string, collecting every
+	// malformed parameter.
 	var filter models.Filter
+	var v validation.Validator
+	var err error
 	values := r.URL.Query()
-	filter.Title = parseurl.ParseString(values, "song", "")
-	filter.Group = parseurl.ParseString(values, "group", "")
-	filter.ReleaseDate = parseurl.ParseTime(values, "release_date", time.Time{})
+	filter.Titles = parseurl.ParseStrings(values, "song")
+	filter.Groups = parseurl.ParseStrings(values, "group")
+	filter.ReleaseDate, err = parseurl.ParseTime(values, "release_date", time.Time{})
+	v.AddError("release_date", err)
+	filter.ReleaseDateFrom, err = parseurl.ParseTime(values, "release_date_from", time.Time{})
+	v.AddError("release_date_from", err)
+	filter.ReleaseDateTo, err = parseurl.ParseTime(values, "release_date_to", time.Time{})
+	v.AddError("release_date_to", err)
+	filter.Year, err = parseurl.ParseInt(values, "year", 0)
+	v.AddError("year", err)
 	filter.Text = parseurl.ParseString(values, "text", "")
 	filter.Link = parseurl.ParseString(values, "link", "")
-	filter.Limit = parseurl.ParseInt(values, "limit", 0)
-	filter.Offset = parseurl.ParseInt(values, "offset", 0)
+	filter.Query = parseurl.ParseString(values, "q", "")
+	filter.TitleMatch, err = parseMatch(parseurl.ParseString(values, "song_match", models.MatchExact))
+	v.AddError("song_match", err)
+	filter.GroupMatch, err = parseMatch(parseurl.ParseString(values, "group_match", models.MatchExact))
+	v.AddError("group_match", err)
+	parsePagination(values, &filter, &v)
+
+	// Validate the parsed values.
+	validation.Filter(&v, &filter)
+	if err = v.Err(); err != nil {
+		h.log.Warn("invalid query parameters", sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
 
 	h.log.Debug("filter parameters extracted", slog.Any("filter", filter))
 
-	// Call the service layer to retrieve the filtered list of songs.
-	songs, err := h.service.ReadFilteredSongs(&filter)
+	// Call the service layer to retrieve the filtered page of songs.
+	page, err := h.service.ReadFilteredSongs(r.Context(), &filter)
 	if err != nil {
-		h.log.Error("failed to retrieve songs by filter", sl.Error(err))
-		http.Error(w, "Failed to retrieve songs", http.StatusBadRequest)
+		switch {
+		case errors.Is(err, services.ErrCursorWithSearch):
+			h.log.Warn("cursor used together with search")
+		case errors.Is(err, cursor.ErrInvalidCursor):
+			h.log.Warn("cursor doesn't match the requested sort")
+		default:
+			h.log.Error("failed to retrieve songs by filter", sl.Error(err))
+		}
+		writeError(w, r, err)
 		return
 	}
-	h.log.Info("songs retrieved successfully", slog.Int("count", len(songs)))
+	h.log.Info("songs retrieved successfully", slog.Int("count", len(page.Items)))
 
-	// Return the filtered songs in the response.
+	// Return the page of songs in the response.
+	w.Header().Set("Link", paginationLinks(r, &filter, page))
 	w.WriteHeader(http.StatusOK)
 	encoder := json.NewEncoder(w)
 	encoder.SetIndent(" ", "\t")
-	err = encoder.Encode(&songs)
+	err = encoder.Encode(page)
 	if err != nil {
 		h.log.Error("failed to encode songs", sl.Error(err))
-		http.Error(w, "Failed to encode", http.StatusInternalServerError)
 		return
 	}
 }
+
+var (
+	ErrInvalidMatch = errors.New("invalid match mode")
+)
+
+// parseMatch checks the value of a *_match query parameter.
+func parseMatch(value string) (string, error) {
+	switch value {
+	case models.MatchExact, models.MatchPrefix, models.MatchContains:
+		return value, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidMatch, value)
+	}
+}
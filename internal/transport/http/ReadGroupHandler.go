@@ -0,0 +1,52 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+)
+
+// @Summary Retrieve a group by ID
+// @Description Retrieves a group and the number of its songs by the group ID.
+// @Tags groups
+// @Produce json
+// @Param id path int true "Group ID"
+// @Success 200 {object} models.Group "Successfully retrieved group"
+// @Failure 400 {object} models.Problem "Invalid group ID"
+// @Failure 404 {object} models.Problem "Group not found"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Router /groups/{id} [get]
+func (h *Handler) ReadGroup(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to read a group")
+
+	// Parse the group ID from the URL parameter.
+	idString := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		h.log.Error("failed to parse group ID", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid group ID")
+		return
+	}
+
+	// Call the service layer to retrieve the group.
+	group, err := h.service.ReadGroupByID(r.Context(), id)
+	if err != nil {
+		h.log.Error("failed to retrieve group", slog.Int("id", id), sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+
+	// Return the group in the response.
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent(" ", "\t")
+	err = encoder.Encode(group)
+	if err != nil {
+		h.log.Error("failed to encode group", sl.Error(err))
+		return
+	}
+}
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	parseurl "github.com/notblinkyet/song-library-api/internal/lib/ParseURL"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+	"github.com/notblinkyet/song-library-api/internal/lib/validation"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// @Summary Retrieve songs of a group
+// @Description Retrieves the songs of a group by the group ID.
+// @Tags groups
+// @Produce json
+// @Param id path int true "Group ID"
+// @Param limit query int false "Maximum number of songs to return"
+// @Param offset query int false "Number of songs to skip"
+// @Success 200 {array} models.Song "Successfully retrieved songs"
+// @Failure 400 {object} models.Problem "Invalid group ID"
+// @Failure 404 {object} models.Problem "Group not found"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Router /groups/{id}/songs [get]
+func (h *Handler) ReadGroupSongs(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to read songs of a group")
+
+	// Parse the group ID from the URL parameter.
+	idString := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		h.log.Error("failed to parse group ID", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid group ID")
+		return
+	}
+
+	// Extract pagination parameters from the query string.
+	var filter models.Filter
+	var v validation.Validator
+	values := r.URL.Query()
+	filter.Limit, err = parseurl.ParseInt(values, "limit", 0)
+	v.AddError("limit", err)
+	filter.Offset, err = parseurl.ParseInt(values, "offset", 0)
+	v.AddError("offset", err)
+	validation.Filter(&v, &filter)
+	if err = v.Err(); err != nil {
+		h.log.Warn("invalid pagination parameters", sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+
+	// Call the service layer to retrieve the songs of the group.
+	songs, err := h.service.ReadGroupSongs(r.Context(), id, &filter)
+	if err != nil {
+		h.log.Error("failed to retrieve songs of the group", slog.Int("id", id), sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+	if songs == nil {
+		songs = []models.Song{}
+	}
+	h.log.Info("songs of the group retrieved successfully", slog.Int("id", id), slog.Int("count", len(songs)))
+
+	// Return the songs in the response.
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent(" ", "\t")
+	err = encoder.Encode(&songs)
+	if err != nil {
+		h.log.Error("failed to encode songs", sl.Error(err))
+		return
+	}
+}
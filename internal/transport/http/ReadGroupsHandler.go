@@ -0,0 +1,39 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+)
+
+// @Summary List groups
+// @Description Retrieves all groups together with the number of songs in each of them.
+// @Tags groups
+// @Produce json
+// @Success 200 {array} models.Group "Successfully retrieved groups"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Router /groups [get]
+func (h *Handler) ReadGroups(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to read groups")
+
+	// Call the service layer to retrieve all groups.
+	groups, err := h.service.ReadGroups(r.Context())
+	if err != nil {
+		h.log.Error("failed to retrieve groups", sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+	h.log.Info("groups retrieved successfully", slog.Int("count", len(groups)))
+
+	// Return the groups in the response.
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent(" ", "\t")
+	err = encoder.Encode(&groups)
+	if err != nil {
+		h.log.Error("failed to encode groups", sl.Error(err))
+		return
+	}
+}
@@ -0,0 +1,53 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+)
+
+// @Summary Retrieve an enrichment job by ID
+// @Description Retrieves the status of the job that fetches the details of a song created asynchronously.
+// @Description A failed attempt is retried at nextRunAt; after the last attempt the job and its song get the failed status.
+// @Tags jobs
+// @Produce json
+// @Param id path int true "Job ID"
+// @Success 200 {object} models.Job "Successfully retrieved job"
+// @Failure 400 {object} models.Problem "Invalid job ID"
+// @Failure 404 {object} models.Problem "Job not found"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Router /jobs/{id} [get]
+func (h *Handler) ReadJob(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to read a job")
+
+	// Parse the job ID from the URL parameter.
+	idString := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		h.log.Error("failed to parse job ID", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid job ID")
+		return
+	}
+
+	// Call the service layer to retrieve the job.
+	job, err := h.service.ReadJob(r.Context(), id)
+	if err != nil {
+		h.log.Error("failed to retrieve job", slog.Int("id", id), sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+
+	// Return the job in the response.
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent(" ", "\t")
+	err = encoder.Encode(job)
+	if err != nil {
+		h.log.Error("failed to encode job", sl.Error(err))
+		return
+	}
+}
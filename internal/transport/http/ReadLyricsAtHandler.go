@@ -0,0 +1,68 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	parseurl "github.com/notblinkyet/song-library-api/internal/lib/ParseURL"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+	"github.com/notblinkyet/song-library-api/internal/lib/validation"
+)
+
+// @Summary Retrieve the line sung at a playback position
+// @Description Returns the line of the synced lyrics sung at the playback position and the next line with text. current is null before the first line, after a line has ended and during pauses; next is null after the last line.
+// @Tags synced lyrics
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param t query number true "Playback position in seconds, e.g. 83.5"
+// @Success 200 {object} models.LyricsPosition "Current and next line"
+// @Failure 400 {object} models.Problem "Invalid song ID or position"
+// @Failure 404 {object} models.Problem "Song not found or has no synced lyrics"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Router /songs/{id}/lyrics/at [get]
+func (h *Handler) ReadLyricsAt(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to read lyrics at a position")
+
+	// Parse the song ID from the URL parameter.
+	idString := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		h.log.Error("failed to parse song ID", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid song ID")
+		return
+	}
+
+	// Parse the playback position.
+	var v validation.Validator
+	v.Check(r.URL.Query().Get("t") != "", "t", "is required")
+	t, err := parseurl.ParseFloat(r.URL.Query(), "t", 0)
+	v.AddError("t", err)
+	v.Check(t >= 0, "t", "must not be negative")
+	if err = v.Err(); err != nil {
+		h.log.Warn("invalid query parameters", sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+
+	// Call the service layer to find the lines.
+	position, err := h.service.LyricsAt(r.Context(), id, t)
+	if err != nil {
+		h.log.Error("failed to retrieve lyrics at position", slog.Int("id", id), sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+	h.log.Debug("lyrics at position retrieved successfully", slog.Int("id", id), slog.Float64("t", t))
+
+	// Return the lines in the response.
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent(" ", "\t")
+	err = encoder.Encode(position)
+	if err != nil {
+		h.log.Error("failed to encode lyrics position", sl.Error(err))
+		return
+	}
+}
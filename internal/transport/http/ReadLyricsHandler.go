@@ -0,0 +1,102 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	parseurl "github.com/notblinkyet/song-library-api/internal/lib/ParseURL"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+	"github.com/notblinkyet/song-library-api/internal/lib/validation"
+	"github.com/notblinkyet/song-library-api/internal/models"
+	"github.com/notblinkyet/song-library-api/internal/services"
+)
+
+// @Summary Retrieve parts of the lyrics of a song
+// @Description Retrieves the lines of a song grouped by sections, which are numbered as verses like in GET /songs/{id}/sections. Only the lines matching every given parameter are returned. A range that starts beyond the last verse or line is an error, while its end is limited to the last one. total_verses and total_lines count the whole song. With lang, or an Accept-Language that prefers one of the translations of the song, the lyrics of the translation are returned.
+// @Tags songs
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param verses query string false "Verses by number, e.g. 1-3,5 or 4- for the 4th verse to the end"
+// @Param lines query string false "Lines by number, e.g. 10-20"
+// @Param section query string false "Type of the sections" Enums(intro, verse, pre-chorus, chorus, bridge, outro, other)
+// @Param lang query string false "BCP 47 tag of the translation to read, e.g. en or pt-BR"
+// @Param Accept-Language header string false "Preferred languages; the original text is used if no translation matches"
+// @Param If-None-Match header string false "ETag of a cached response; 304 is returned if the song hasn't changed"
+// @Success 200 {object} models.Lyrics "Selected lyrics"
+// @Header 200 {string} ETag "Version of the song and of the translation"
+// @Header 200 {string} Content-Language "Language of the translation"
+// @Success 304 "Song hasn't changed"
+// @Failure 400 {object} models.Problem "Invalid parameters, or the song doesn't have the requested verses or lines"
+// @Failure 404 {object} models.Problem "Song or translation not found"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Router /songs/{id}/lyrics [get]
+func (h *Handler) ReadLyrics(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to read lyrics")
+
+	// Parse the song ID from the URL parameter.
+	idString := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		h.log.Error("failed to parse song ID", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid song ID")
+		return
+	}
+
+	// Parse the selection of the lyrics.
+	var (
+		v     validation.Validator
+		query models.LyricsQuery
+	)
+	query.Verses, err = parseurl.ParseRanges(r.URL.Query(), "verses")
+	v.AddError("verses", err)
+	query.Lines, err = parseurl.ParseRanges(r.URL.Query(), "lines")
+	v.AddError("lines", err)
+	query.Section = parseurl.ParseString(r.URL.Query(), "section", "")
+	query.Language = parseLanguage(r, &v)
+	validation.LyricsQuery(&v, &query)
+	if err = v.Err(); err != nil {
+		h.log.Warn("invalid query parameters", sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+
+	// Call the service layer to retrieve the lyrics.
+	lyrics, err := h.service.ReadLyrics(r.Context(), id, &query)
+	if err != nil {
+		if errors.Is(err, services.ErrVerseOutOfBound) || errors.Is(err, services.ErrLineOutOfBound) {
+			h.log.Warn("song does not contain requested lyrics", slog.Int("id", id), sl.Error(err))
+		} else {
+			h.log.Error("failed to retrieve lyrics", slog.Int("id", id), sl.Error(err))
+		}
+		writeError(w, r, err)
+		return
+	}
+	h.log.Info("lyrics retrieved successfully", slog.Int("id", id), slog.Int("verses", len(lyrics.Verses)))
+
+	// Let clients revalidate their cached copy using the song version and
+	// the version of the translation.
+	tag := etag(lyrics.Version)
+	if lyrics.Lang != "" {
+		tag = translationETag(lyrics.Version, lyrics.Lang, lyrics.LangVersion)
+	}
+	setLanguage(w, lyrics.Lang)
+	w.Header().Set("ETag", tag)
+	if match := r.Header.Get("If-None-Match"); match != "" && matchTag(match, tag, true) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	// Return the lyrics in the response.
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent(" ", "\t")
+	err = encoder.Encode(lyrics)
+	if err != nil {
+		h.log.Error("failed to encode lyrics", sl.Error(err))
+		return
+	}
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+)
+
+// @Summary Retrieve the structure of the lyrics of a song
+// @Description Splits the lyrics into typed sections (intro, verse, pre-chorus, chorus, bridge, outro, other). Sections are detected from labels like "[Chorus]", "Verse 2:" or "(Bridge)", and unlabelled sections that repeat are treated as choruses. Lines are numbered from 1 across the whole song, skipping blank lines and labels.
+// @Tags songs
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param If-None-Match header string false "ETag of a cached response; 304 is returned if the song hasn't changed"
+// @Success 200 {object} models.SongSections "Sections of the song"
+// @Header 200 {string} ETag "Version of the song"
+// @Success 304 "Song hasn't changed"
+// @Failure 400 {object} models.Problem "Invalid song ID"
+// @Failure 404 {object} models.Problem "Song not found"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Router /songs/{id}/sections [get]
+func (h *Handler) ReadSections(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to read song sections")
+
+	// Parse the song ID from the URL parameter.
+	idString := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		h.log.Error("failed to parse song ID", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid song ID")
+		return
+	}
+
+	// Call the service layer to retrieve the sections.
+	sections, err := h.service.ReadSections(r.Context(), id)
+	if err != nil {
+		h.log.Error("failed to retrieve song sections", slog.Int("id", id), sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+	h.log.Info("song sections retrieved successfully", slog.Int("id", id), slog.Int("count", len(sections.Sections)))
+
+	// Let clients revalidate their cached copy using the song version.
+	w.Header().Set("ETag", etag(sections.Version))
+	if match := r.Header.Get("If-None-Match"); match != "" && matchETag(match, sections.Version, true) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	// Return the sections in the response.
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent(" ", "\t")
+	err = encoder.Encode(sections)
+	if err != nil {
+		h.log.Error("failed to encode song sections", sl.Error(err))
+		return
+	}
+}
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+)
+
+// @Summary Retrieve a version of a song
+// @Description Retrieves the state of a song right after the operation recorded in the given version.
+// @Tags versions
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param version path int true "Version number, starting from 1"
+// @Success 200 {object} models.SongVersion "Version of the song"
+// @Failure 400 {object} models.Problem "Invalid song ID or version"
+// @Failure 404 {object} models.Problem "Version not found"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Router /songs/{id}/versions/{version} [get]
+func (h *Handler) ReadSongVersion(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to read a song version")
+
+	// Parse the song ID and the version number from the URL parameters.
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.log.Error("failed to parse song ID", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid song ID")
+		return
+	}
+	version, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil {
+		h.log.Error("failed to parse version", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid version")
+		return
+	}
+
+	// Call the service layer to retrieve the version.
+	songVersion, err := h.service.ReadSongVersion(r.Context(), id, version)
+	if err != nil {
+		h.log.Error("failed to retrieve song version", slog.Int("id", id), slog.Int("version", version), sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+
+	// Return the version in the response.
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent(" ", "\t")
+	err = encoder.Encode(songVersion)
+	if err != nil {
+		h.log.Error("failed to encode song version", sl.Error(err))
+		return
+	}
+}
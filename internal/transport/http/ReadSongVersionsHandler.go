@@ -0,0 +1,53 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+)
+
+// @Summary Retrieve the change history of a song
+// @Description Retrieves all versions of a song, oldest first. A version is recorded on every create, update, delete and restore.
+// @Tags versions
+// @Produce json
+// @Param id path int true "Song ID"
+// @Success 200 {array} models.SongVersion "Versions of the song"
+// @Failure 400 {object} models.Problem "Invalid song ID"
+// @Failure 404 {object} models.Problem "Song has no history"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Router /songs/{id}/versions [get]
+func (h *Handler) ReadSongVersions(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to read song versions")
+
+	// Parse the song ID from the URL parameter.
+	idString := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		h.log.Error("failed to parse song ID", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid song ID")
+		return
+	}
+
+	// Call the service layer to retrieve the history of the song.
+	versions, err := h.service.ReadSongVersions(r.Context(), id)
+	if err != nil {
+		h.log.Error("failed to retrieve song versions", slog.Int("id", id), sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+	h.log.Info("song versions retrieved successfully", slog.Int("id", id), slog.Int("count", len(versions)))
+
+	// Return the versions in the response.
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent(" ", "\t")
+	err = encoder.Encode(&versions)
+	if err != nil {
+		h.log.Error("failed to encode song versions", sl.Error(err))
+		return
+	}
+}
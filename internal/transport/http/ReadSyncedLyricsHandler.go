@@ -0,0 +1,82 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	parseurl "github.com/notblinkyet/song-library-api/internal/lib/ParseURL"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+	"github.com/notblinkyet/song-library-api/internal/lib/synced"
+	"github.com/notblinkyet/song-library-api/internal/lib/validation"
+)
+
+// @Summary Retrieve the synced lyrics of a song
+// @Description Retrieves the lyrics of a song synchronised with playback as JSON, LRC or WebVTT. In LRC the ends of lines followed by a pause are written as empty lines; in WebVTT lines without an end last until the next line.
+// @Tags synced lyrics
+// @Produce json
+// @Produce application/x-lrc
+// @Produce text/vtt
+// @Param id path int true "Song ID"
+// @Param format query string false "Format of the lyrics" Enums(json, lrc, vtt) default(json)
+// @Success 200 {object} models.SyncedLyrics "Synced lyrics"
+// @Failure 400 {object} models.Problem "Invalid song ID or format"
+// @Failure 404 {object} models.Problem "Song not found or has no synced lyrics"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Router /songs/{id}/lyrics/synced [get]
+func (h *Handler) ReadSyncedLyrics(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to read synced lyrics")
+
+	// Parse the song ID from the URL parameter.
+	idString := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		h.log.Error("failed to parse song ID", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid song ID")
+		return
+	}
+
+	// Parse the requested format.
+	var v validation.Validator
+	format := parseurl.ParseString(r.URL.Query(), "format", synced.FormatJSON)
+	v.Check(format == synced.FormatJSON || format == synced.FormatLRC || format == synced.FormatVTT,
+		"format", "must be one of json, lrc, vtt")
+	if err = v.Err(); err != nil {
+		h.log.Warn("invalid query parameters", sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+
+	// Call the service layer to retrieve the lyrics.
+	lyrics, err := h.service.ReadSyncedLyrics(r.Context(), id)
+	if err != nil {
+		h.log.Error("failed to retrieve synced lyrics", slog.Int("id", id), sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+	h.log.Info("synced lyrics retrieved successfully", slog.Int("id", id), slog.String("format", format))
+
+	// Return the lyrics in the requested format.
+	switch format {
+	case synced.FormatLRC:
+		w.Header().Set("Content-Type", mediaTypeLRC+"; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, err = io.WriteString(w, synced.EncodeLRC(lyrics.Lines))
+	case synced.FormatVTT:
+		w.Header().Set("Content-Type", mediaTypeVTT+"; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, err = io.WriteString(w, synced.EncodeVTT(lyrics.Lines))
+	default:
+		w.WriteHeader(http.StatusOK)
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent(" ", "\t")
+		err = encoder.Encode(lyrics)
+	}
+	if err != nil {
+		h.log.Error("failed to write synced lyrics", sl.Error(err))
+		return
+	}
+}
@@ -0,0 +1,62 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/notblinkyet/song-library-api/internal/lib/langtag"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+)
+
+// @Summary Retrieve a translation of a song
+// @Description Retrieves the text of a song translated into a language. The tag is matched in its canonical form, so en-us and en_US both mean en-US.
+// @Tags translations
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param lang path string true "BCP 47 language tag, e.g. en or pt-BR"
+// @Success 200 {object} models.Translation "Translation"
+// @Failure 400 {object} models.Problem "Invalid song ID or language tag"
+// @Failure 404 {object} models.Problem "Song or translation not found"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Router /songs/{id}/translations/{lang} [get]
+func (h *Handler) ReadTranslation(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to read translation")
+
+	// Parse the song ID and the language from the URL parameters.
+	idString := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		h.log.Error("failed to parse song ID", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid song ID")
+		return
+	}
+	lang, err := langtag.Canonical(chi.URLParam(r, "lang"))
+	if err != nil {
+		h.log.Warn("failed to parse language tag", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid language tag")
+		return
+	}
+
+	// Call the service layer to retrieve the translation.
+	translation, err := h.service.ReadTranslation(r.Context(), id, lang)
+	if err != nil {
+		h.log.Error("failed to retrieve translation", slog.Int("id", id), slog.String("lang", lang), sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+	h.log.Info("translation retrieved successfully", slog.Int("id", id), slog.String("lang", lang))
+
+	// Return the translation in the response.
+	w.Header().Set("Content-Language", lang)
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent(" ", "\t")
+	err = encoder.Encode(translation)
+	if err != nil {
+		h.log.Error("failed to encode translation", sl.Error(err))
+		return
+	}
+}
@@ -0,0 +1,53 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+)
+
+// @Summary Retrieve the translations of a song
+// @Description Retrieves all translations of the text of a song ordered by language. outdated is set for translations made before the song last changed.
+// @Tags translations
+// @Produce json
+// @Param id path int true "Song ID"
+// @Success 200 {array} models.Translation "Translations of the song"
+// @Failure 400 {object} models.Problem "Invalid song ID"
+// @Failure 404 {object} models.Problem "Song not found"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Router /songs/{id}/translations [get]
+func (h *Handler) ReadTranslations(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to read translations")
+
+	// Parse the song ID from the URL parameter.
+	idString := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		h.log.Error("failed to parse song ID", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid song ID")
+		return
+	}
+
+	// Call the service layer to retrieve the translations.
+	translations, err := h.service.ReadTranslations(r.Context(), id)
+	if err != nil {
+		h.log.Error("failed to retrieve translations", slog.Int("id", id), sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+	h.log.Info("translations retrieved successfully", slog.Int("id", id), slog.Int("count", len(translations)))
+
+	// Return the translations in the response.
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent(" ", "\t")
+	err = encoder.Encode(&translations)
+	if err != nil {
+		h.log.Error("failed to encode translations", sl.Error(err))
+		return
+	}
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/notblinkyet/song-library-api/internal/lib/cursor"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+	"github.com/notblinkyet/song-library-api/internal/lib/validation"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// @Summary Retrieve songs in the trash
+// @Description Retrieves a page of deleted songs that can still be restored. Songs are purged permanently after the configured retention period.
+// @Tags trash
+// @Produce json
+// @Param limit query int false "Maximum number of songs on the page"
+// @Param offset query int false "Number of songs to skip (offset pagination)"
+// @Param cursor query string false "Cursor from next_cursor of the previous page (cursor pagination)"
+// @Param sort query string false "Comma-separated sort keys: id, title (or song), group, release_date; prefix a key with - for descending order"
+// @Param total query bool false "Include the total number of songs in the trash"
+// @Success 200 {object} models.SongPage "Successfully retrieved songs"
+// @Header 200 {string} Link "RFC 8288 links to the first, next and previous pages"
+// @Failure 400 {object} models.Problem "Invalid pagination parameters"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Router /trash [get]
+func (h *Handler) ReadTrash(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to read trash")
+
+	// Extract pagination parameters from the query string.
+	var filter models.Filter
+	var v validation.Validator
+	parsePagination(r.URL.Query(), &filter, &v)
+	validation.Filter(&v, &filter)
+	if err := v.Err(); err != nil {
+		h.log.Warn("invalid pagination parameters", sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+
+	// Call the service layer to retrieve the page of deleted songs.
+	page, err := h.service.ReadTrash(r.Context(), &filter)
+	if err != nil {
+		if errors.Is(err, cursor.ErrInvalidCursor) {
+			h.log.Warn("cursor doesn't match the requested sort")
+		} else {
+			h.log.Error("failed to retrieve trash", sl.Error(err))
+		}
+		writeError(w, r, err)
+		return
+	}
+	h.log.Info("trash retrieved successfully", slog.Int("count", len(page.Items)))
+
+	// Return the page of songs in the response.
+	w.Header().Set("Link", paginationLinks(r, &filter, page))
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent(" ", "\t")
+	err = encoder.Encode(page)
+	if err != nil {
+		h.log.Error("failed to encode songs", sl.Error(err))
+		return
+	}
+}
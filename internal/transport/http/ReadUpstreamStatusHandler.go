@@ -0,0 +1,31 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+)
+
+// @Summary Status of the song info API
+// @Description Reports the circuit breaker state of the external API used to fetch song details.
+// @Description closed means calls go through, open means calls are rejected until retryAt, half-open means a trial call is being made.
+// @Tags status
+// @Produce json
+// @Success 200 {array} models.UpstreamStatus "Status of the external API"
+// @Router /status/upstream [get]
+func (h *Handler) ReadUpstreamStatus(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to read upstream status")
+
+	status := h.service.UpstreamStatus()
+
+	// Return the status in the response.
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent(" ", "\t")
+	err := encoder.Encode(&status)
+	if err != nil {
+		h.log.Error("failed to encode upstream status", sl.Error(err))
+		return
+	}
+}
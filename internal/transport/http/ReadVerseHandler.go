@@ -10,20 +10,28 @@ import (
 	"github.com/go-chi/chi"
 	parseurl "github.com/notblinkyet/song-library-api/internal/lib/ParseURL"
 	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+	"github.com/notblinkyet/song-library-api/internal/lib/validation"
 	"github.com/notblinkyet/song-library-api/internal/services"
 )
 
 // @Summary Retrieve verses of a song by ID
-// @Description Retrieves the verses of a song by its ID.
+// @Description Retrieves the verses of a song by its ID. With lang, or an Accept-Language that prefers one of the translations of the song, the verses of the translation are returned, each with the verse of the original text it is aligned with.
 // @Tags songs
 // @Accept json
 // @Produce json
 // @Param id path int true "Song ID"
 // @Param start query int false "Start index for verse retrieval (1-based index). Defaults to 1."
-// @Param count query int false "Number of verses to retrieve. Defaults to 1."
+// @Param count query int false "Number of verses to retrieve, not more than the verses left. Defaults to 1."
+// @Param lang query string false "BCP 47 tag of the translation to read, e.g. en or pt-BR"
+// @Param Accept-Language header string false "Preferred languages; the original text is used if no translation matches"
+// @Param If-None-Match header string false "ETag of a cached response; 304 is returned if the song hasn't changed"
 // @Success 200 {object} []models.Verse "Verses of the song"
-// @Failure 400 {object} string "Invalid request parameters or song does not contain requested verses."
-// @Failure 404 {object} string "Song not found."
+// @Header 200 {string} ETag "Version of the song and of the translation"
+// @Header 200 {string} Content-Language "Language of the translation"
+// @Success 304 "Song hasn't changed"
+// @Failure 400 {object} models.Problem "Invalid request parameters or song does not contain requested verses."
+// @Failure 404 {object} models.Problem "Song or translation not found."
+// @Failure 500 {object} models.Problem "Internal server error."
 // @Router /songs/{id} [get]
 func (h *Handler) ReadVerse(w http.ResponseWriter, r *http.Request) {
 	h.log.Info("received request to read text by ID")
@@ -33,34 +41,58 @@ func (h *Handler) ReadVerse(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(idString)
 	if err != nil {
 		h.log.Error("failed to parse song ID", sl.Error(err))
-		http.Error(w, "Invalid song ID", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid song ID")
 		return
 	}
 	h.log.Debug("parsed song ID", slog.Int("id", id))
 
 	// Parse additional query parameters for verse retrieval.
-	start := parseurl.ParseInt(r.URL.Query(), "start", 1)
-	count := parseurl.ParseInt(r.URL.Query(), "count", 1)
+	var v validation.Validator
+	start, err := parseurl.ParseInt(r.URL.Query(), "start", 1)
+	v.AddError("start", err)
+	v.Check(start >= 1, "start", "must be at least 1")
+	count, err := parseurl.ParseInt(r.URL.Query(), "count", 1)
+	v.AddError("count", err)
+	v.Check(count >= 0, "count", "must not be negative")
+	language := parseLanguage(r, &v)
+	if err = v.Err(); err != nil {
+		h.log.Warn("invalid query parameters", sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
 
 	// Call the service layer to retrieve the requested verses.
-	verse, err := h.service.ReadVerse(id, start, count)
+	verses, err := h.service.ReadVerse(r.Context(), id, start, count, &language)
 	if err != nil {
 		if errors.Is(err, services.ErrVerseOutOfBound) {
 			h.log.Warn("song does not contain requested verses", slog.Int("id", id))
-			http.Error(w, services.ErrVerseOutOfBound.Error(), http.StatusBadRequest)
-			return
+		} else {
+			h.log.Error("failed to retrieve verses", sl.Error(err))
 		}
-		h.log.Error("failed to retrieve verses", sl.Error(err))
-		http.Error(w, "failed to retrieve verses", http.StatusBadRequest)
+		writeError(w, r, err)
 		return
 	}
 	h.log.Info("verses retrieved successfully", slog.Int("id", id))
 
+	// Let clients revalidate their cached copy using the song version and
+	// the version of the translation.
+	tag, lang := etag(verses.Version), ""
+	if verses.Translation != nil {
+		lang = verses.Translation.Lang
+		tag = translationETag(verses.Version, lang, verses.Translation.Version)
+	}
+	setLanguage(w, lang)
+	w.Header().Set("ETag", tag)
+	if match := r.Header.Get("If-None-Match"); match != "" && matchTag(match, tag, true) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
 	// Return the verses in the response.
 	w.WriteHeader(http.StatusOK)
 	encoder := json.NewEncoder(w)
 	encoder.SetIndent(" ", "\t")
-	err = encoder.Encode(verse)
+	err = encoder.Encode(verses.Verses)
 	if err != nil {
 		h.log.Error("failed to encode verses", sl.Error(err))
 		return
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	parseurl "github.com/notblinkyet/song-library-api/internal/lib/ParseURL"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+	"github.com/notblinkyet/song-library-api/internal/lib/validation"
+)
+
+// @Summary Refresh song details
+// @Description Fetches the release date, text and link of the song from the song info API again and returns the fields that changed with their values before and after.
+// @Description Empty fields and fields supplied by a provider are replaced; fields edited by users are kept unless force is set.
+// @Description With dry_run the changes are only reported and the song is left as is.
+// @Tags songs
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param dry_run query bool false "Only report the changes"
+// @Param force query bool false "Also replace fields edited by users"
+// @Success 200 {object} models.SongRefresh "Changes of the song"
+// @Header 200 {string} ETag "Version of the song"
+// @Failure 400 {object} models.Problem "Invalid song ID or query parameters, or the song info API rejected the song"
+// @Failure 404 {object} models.Problem "Song not found"
+// @Failure 412 {object} models.Problem "Song has been modified while it was being refreshed"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Failure 502 {object} models.Problem "Song info API failed"
+// @Failure 503 {object} models.Problem "Song info API is unavailable"
+// @Router /songs/{id}/refresh [post]
+func (h *Handler) RefreshSong(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to refresh a song")
+
+	// Parse the song ID from the URL parameter.
+	idString := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		h.log.Error("failed to parse song ID", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid song ID")
+		return
+	}
+
+	// Parse the options of the refresh.
+	var v validation.Validator
+	values := r.URL.Query()
+	dryRun, err := parseurl.ParseBool(values, "dry_run", false)
+	v.AddError("dry_run", err)
+	force, err := parseurl.ParseBool(values, "force", false)
+	v.AddError("force", err)
+	if err = v.Err(); err != nil {
+		h.log.Warn("invalid refresh options", sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+
+	// Call the service layer to refresh the song.
+	res, err := h.service.RefreshSong(r.Context(), id, force, dryRun)
+	if err != nil {
+		h.log.Error("failed to refresh song", slog.Int("id", id), sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+	h.log.Info("song refreshed", slog.Int("id", id), slog.Int("changes", len(res.Changes)), slog.Bool("applied", res.Applied))
+
+	// Return the changes in the response.
+	w.Header().Set("ETag", etag(res.Song.Version))
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent(" ", "\t")
+	err = encoder.Encode(res)
+	if err != nil {
+		h.log.Error("failed to encode refresh result", sl.Error(err))
+		return
+	}
+}
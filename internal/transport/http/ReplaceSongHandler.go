@@ -0,0 +1,57 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+	"github.com/notblinkyet/song-library-api/internal/lib/validation"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// @Summary Replace a song by ID
+// @Description Replaces all editable fields of an existing song. Fields missing from the body are cleared; song and group are required.
+// @Tags songs
+// @Accept json
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param If-Match header string false "ETag of the song version the changes are based on"
+// @Param song body models.SongRequest true "New song fields"
+// @Success 200 {object} models.Song "Successfully replaced song"
+// @Header 200 {string} ETag "New version of the song"
+// @Failure 400 {object} models.Problem "Invalid request (e.g., invalid JSON or missing required fields)."
+// @Failure 404 {object} models.Problem "Song not found."
+// @Failure 412 {object} models.Problem "Song has been modified since the version in If-Match."
+// @Failure 500 {object} models.Problem "Internal server error during the update process."
+// @Router /songs/{id} [put]
+func (h *Handler) ReplaceSong(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to replace a song")
+
+	// Parse and decode the new song fields from the request body.
+	var req models.SongRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		h.log.Error("failed to decode request body", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "invalid request body")
+		return
+	}
+	var v validation.Validator
+	validation.SongRequest(&v, &req)
+	if err = v.Err(); err != nil {
+		h.log.Warn("invalid song", sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+
+	song, ok := h.readSongForUpdate(w, r)
+	if !ok {
+		return
+	}
+
+	// Overwrite the song fields with the new data.
+	req.Apply(song)
+	h.log.Debug("replaced song fields", slog.Any("song", song))
+
+	h.saveSong(w, r, song)
+}
@@ -0,0 +1,53 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+)
+
+// @Summary Restore a song from the trash
+// @Description Takes a deleted song out of the trash.
+// @Tags trash
+// @Produce json
+// @Param id path int true "Song ID"
+// @Success 200 {object} models.Song "Restored song"
+// @Failure 400 {object} models.Problem "Invalid song ID"
+// @Failure 404 {object} models.Problem "Song is not in the trash"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Router /songs/{id}/restore [post]
+func (h *Handler) RestoreSong(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to restore a song")
+
+	// Parse the song ID from the URL parameter.
+	idString := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		h.log.Error("failed to parse song ID", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid song ID")
+		return
+	}
+
+	// Call the service layer to take the song out of the trash.
+	song, err := h.service.RestoreSong(r.Context(), id)
+	if err != nil {
+		h.log.Error("failed to restore song", slog.Int("id", id), sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+	h.log.Info("song restored successfully", slog.Int("id", id))
+
+	// Return the restored song in the response.
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent(" ", "\t")
+	err = encoder.Encode(song)
+	if err != nil {
+		h.log.Error("failed to encode song", sl.Error(err))
+		return
+	}
+}
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+)
+
+// @Summary Restore a version of a song
+// @Description Brings the song back to the state stored in the given version and records the restore as a new version. Deleted songs are recreated with their old ID.
+// @Tags versions
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param version path int true "Version number, starting from 1"
+// @Success 200 {object} models.Song "Restored song"
+// @Failure 400 {object} models.Problem "Invalid song ID or version"
+// @Failure 404 {object} models.Problem "Version not found"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Router /songs/{id}/versions/{version}/restore [post]
+func (h *Handler) RestoreSongVersion(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to restore a song version")
+
+	// Parse the song ID and the version number from the URL parameters.
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.log.Error("failed to parse song ID", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid song ID")
+		return
+	}
+	version, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil {
+		h.log.Error("failed to parse version", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid version")
+		return
+	}
+
+	// Call the service layer to restore the version.
+	song, err := h.service.RestoreSongVersion(r.Context(), id, version)
+	if err != nil {
+		h.log.Error("failed to restore song version", slog.Int("id", id), slog.Int("version", version), sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+	h.log.Info("song version restored successfully", slog.Int("id", id), slog.Int("version", version))
+
+	// Return the restored song in the response.
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent(" ", "\t")
+	err = encoder.Encode(song)
+	if err != nil {
+		h.log.Error("failed to encode song", sl.Error(err))
+		return
+	}
+}
@@ -0,0 +1,120 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/notblinkyet/song-library-api/internal/lib/chordpro"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// Media types of chord charts.
+const (
+	mediaTypeChordPro     = "application/x-chordpro"
+	mediaTypeChordProText = "text/x-chordpro"
+	mediaTypeHTML         = "text/html"
+)
+
+// maxChordChartSize limits the body of PUT /songs/{id}/chords.
+const maxChordChartSize = 1 << 20
+
+// @Summary Attach a chord chart to a song
+// @Description Attaches a ChordPro chord chart to a song, replacing the previous one. The body is the ChordPro document (Content-Type application/x-chordpro, text/x-chordpro or text/plain) or JSON (application/json) like {"source": "..."}.
+// @Description Chords are written in brackets inside the lyrics, like "[G]Hello [C/E]world". The directives title, subtitle, artist, key, capo, comment, chorus and start_of_/end_of_ sections are recognised; tabs and grids are kept verbatim. The plain text of the song is not changed.
+// @Tags chords
+// @Accept json
+// @Accept application/x-chordpro
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param chart body models.ChordChartRequest true "Chord chart"
+// @Success 200 {object} models.ChordChart "Saved chord chart"
+// @Failure 400 {object} models.Problem "Invalid song ID or chord chart"
+// @Failure 404 {object} models.Problem "Song not found"
+// @Failure 413 {object} models.Problem "Body is too large"
+// @Failure 415 {object} models.Problem "Unsupported Content-Type"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Router /songs/{id}/chords [put]
+func (h *Handler) SaveChordChart(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to save chord chart")
+
+	// Parse the song ID from the URL parameter.
+	idString := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		h.log.Error("failed to parse song ID", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid song ID")
+		return
+	}
+
+	// Pick the format from the Content-Type.
+	mediaType := mediaTypeJSON
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err = mime.ParseMediaType(contentType)
+		if err != nil {
+			h.log.Warn("failed to parse Content-Type", sl.Error(err))
+			writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "invalid Content-Type")
+			return
+		}
+	}
+	switch mediaType {
+	case mediaTypeJSON, mediaTypeChordPro, mediaTypeChordProText, mediaTypePlainText:
+	default:
+		h.log.Warn("unsupported Content-Type", slog.String("contentType", mediaType))
+		writeProblem(w, r, http.StatusUnsupportedMediaType, CodeUnsupportedMediaType, "unsupported Content-Type "+mediaType)
+		return
+	}
+
+	// Read the chart.
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxChordChartSize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.log.Warn("chord chart is too large")
+			writeProblem(w, r, http.StatusRequestEntityTooLarge, CodeInvalidRequest, "body is too large")
+			return
+		}
+		h.log.Error("failed to read request body", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "failed to read request body")
+		return
+	}
+	source := string(body)
+	if mediaType == mediaTypeJSON {
+		var req models.ChordChartRequest
+		if err = json.Unmarshal(body, &req); err != nil {
+			h.log.Error("failed to decode request body", sl.Error(err))
+			writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "invalid JSON")
+			return
+		}
+		source = req.Source
+	}
+
+	// Call the service layer to save the chart.
+	chart, err := h.service.SaveChordChart(r.Context(), id, source)
+	if err != nil {
+		if errors.Is(err, chordpro.ErrInvalid) {
+			h.log.Warn("invalid chord chart", sl.Error(err))
+		} else {
+			h.log.Error("failed to save chord chart", slog.Int("id", id), sl.Error(err))
+		}
+		writeError(w, r, err)
+		return
+	}
+	h.log.Info("chord chart saved successfully", slog.Int("id", id))
+
+	// Return the saved chart in the response.
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent(" ", "\t")
+	err = encoder.Encode(chart)
+	if err != nil {
+		h.log.Error("failed to encode chord chart", sl.Error(err))
+		return
+	}
+}
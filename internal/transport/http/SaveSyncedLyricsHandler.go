@@ -0,0 +1,132 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+	"github.com/notblinkyet/song-library-api/internal/lib/synced"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// Media types of synced lyrics.
+const (
+	mediaTypeLRC       = "application/x-lrc"
+	mediaTypeLRCText   = "text/x-lrc"
+	mediaTypeVTT       = "text/vtt"
+	mediaTypePlainText = "text/plain"
+)
+
+// maxSyncedLyricsSize limits the body of PUT /songs/{id}/lyrics/synced.
+const maxSyncedLyricsSize = 1 << 20
+
+// @Summary Attach synced lyrics to a song
+// @Description Attaches lyrics synchronised with playback to a song, replacing the previous ones. The body is LRC (Content-Type application/x-lrc, text/x-lrc or text/plain), WebVTT (text/vtt) or JSON (application/json) with lines like {"start": 12.5, "end": 15, "text": "..."}.
+// @Description Lines must start at strictly increasing times, end after they start and not after the next line starts. In LRC, metadata tags are ignored except [offset:ms], lines without text mark pauses, and a line with several timestamps is repeated at each of them.
+// @Tags synced lyrics
+// @Accept json
+// @Accept application/x-lrc
+// @Accept text/vtt
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param lyrics body models.SyncedLyricsRequest true "Synced lyrics"
+// @Success 200 {object} models.SyncedLyrics "Saved lyrics"
+// @Failure 400 {object} models.Problem "Invalid song ID or lyrics"
+// @Failure 404 {object} models.Problem "Song not found"
+// @Failure 413 {object} models.Problem "Body is too large"
+// @Failure 415 {object} models.Problem "Unsupported Content-Type"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Router /songs/{id}/lyrics/synced [put]
+func (h *Handler) SaveSyncedLyrics(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to save synced lyrics")
+
+	// Parse the song ID from the URL parameter.
+	idString := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		h.log.Error("failed to parse song ID", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid song ID")
+		return
+	}
+
+	// Pick the format from the Content-Type.
+	mediaType := mediaTypeJSON
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err = mime.ParseMediaType(contentType)
+		if err != nil {
+			h.log.Warn("failed to parse Content-Type", sl.Error(err))
+			writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "invalid Content-Type")
+			return
+		}
+	}
+	switch mediaType {
+	case mediaTypeJSON, mediaTypeLRC, mediaTypeLRCText, mediaTypePlainText, mediaTypeVTT:
+	default:
+		h.log.Warn("unsupported Content-Type", slog.String("contentType", mediaType))
+		writeProblem(w, r, http.StatusUnsupportedMediaType, CodeUnsupportedMediaType, "unsupported Content-Type "+mediaType)
+		return
+	}
+
+	// Read and parse the lyrics.
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSyncedLyricsSize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.log.Warn("synced lyrics are too large")
+			writeProblem(w, r, http.StatusRequestEntityTooLarge, CodeInvalidRequest, "body is too large")
+			return
+		}
+		h.log.Error("failed to read request body", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "failed to read request body")
+		return
+	}
+	var lines []models.SyncedLine
+	switch mediaType {
+	case mediaTypeJSON:
+		var req models.SyncedLyricsRequest
+		if err = json.Unmarshal(body, &req); err != nil {
+			h.log.Error("failed to decode request body", sl.Error(err))
+			writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "invalid JSON")
+			return
+		}
+		lines = req.Lines
+	case mediaTypeVTT:
+		lines, err = synced.ParseVTT(string(body))
+	default:
+		lines, err = synced.ParseLRC(string(body))
+	}
+	if err != nil {
+		h.log.Warn("invalid synced lyrics", sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+
+	// Call the service layer to save the lyrics.
+	lyrics, err := h.service.SaveSyncedLyrics(r.Context(), id, lines)
+	if err != nil {
+		if errors.Is(err, synced.ErrInvalid) {
+			h.log.Warn("invalid synced lyrics", sl.Error(err))
+		} else {
+			h.log.Error("failed to save synced lyrics", slog.Int("id", id), sl.Error(err))
+		}
+		writeError(w, r, err)
+		return
+	}
+	h.log.Info("synced lyrics saved successfully", slog.Int("id", id), slog.Int("lines", len(lyrics.Lines)))
+
+	// Return the saved lyrics in the response.
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent(" ", "\t")
+	err = encoder.Encode(lyrics)
+	if err != nil {
+		h.log.Error("failed to encode synced lyrics", sl.Error(err))
+		return
+	}
+}
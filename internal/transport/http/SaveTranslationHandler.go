@@ -0,0 +1,97 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/notblinkyet/song-library-api/internal/lib/langtag"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+	"github.com/notblinkyet/song-library-api/internal/lib/validation"
+	"github.com/notblinkyet/song-library-api/internal/models"
+	"github.com/notblinkyet/song-library-api/internal/services"
+)
+
+// @Summary Create or replace a translation of a song
+// @Description Stores the text of a song translated into a language, replacing the previous translation into it. Verses are separated by blank lines like in the text of the song, and the translation must have as many verses and as many sections split by labels like [Chorus], so that GET /songs/{id}?lang= and GET /songs/{id}/lyrics?lang= can align them.
+// @Tags translations
+// @Accept json
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param lang path string true "BCP 47 language tag, e.g. en or pt-BR"
+// @Param translation body models.TranslationRequest true "Translated text"
+// @Success 200 {object} models.Translation "Translation replaced"
+// @Success 201 {object} models.Translation "Translation created"
+// @Header 201 {string} Location "URL of the translation"
+// @Failure 400 {object} models.Problem "Invalid song ID, language tag or text, or the verses don't match the song"
+// @Failure 404 {object} models.Problem "Song not found"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Router /songs/{id}/translations/{lang} [put]
+func (h *Handler) SaveTranslation(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to save translation")
+
+	// Parse the song ID and the language from the URL parameters.
+	idString := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		h.log.Error("failed to parse song ID", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid song ID")
+		return
+	}
+	lang, err := langtag.Canonical(chi.URLParam(r, "lang"))
+	if err != nil {
+		h.log.Warn("failed to parse language tag", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid language tag")
+		return
+	}
+
+	// Parse and validate the request body.
+	var req models.TranslationRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		h.log.Error("failed to decode request body", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "invalid request body")
+		return
+	}
+	var v validation.Validator
+	validation.TranslationRequest(&v, &req)
+	if err = v.Err(); err != nil {
+		h.log.Warn("invalid translation", sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+
+	// Call the service layer to save the translation.
+	translation, created, err := h.service.SaveTranslation(r.Context(), id, lang, req.Text)
+	if err != nil {
+		if errors.Is(err, services.ErrTranslationMisaligned) {
+			h.log.Warn("translation is not aligned with the song", slog.Int("id", id), sl.Error(err))
+		} else {
+			h.log.Error("failed to save translation", slog.Int("id", id), slog.String("lang", lang), sl.Error(err))
+		}
+		writeError(w, r, err)
+		return
+	}
+	h.log.Info("translation saved successfully", slog.Int("id", id), slog.String("lang", lang),
+		slog.Bool("created", created))
+
+	// Return the saved translation in the response.
+	status := http.StatusOK
+	if created {
+		w.Header().Set("Location", fmt.Sprintf("/songs/%d/translations/%s", id, lang))
+		status = http.StatusCreated
+	}
+	w.Header().Set("Content-Language", lang)
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent(" ", "\t")
+	err = encoder.Encode(translation)
+	if err != nil {
+		h.log.Error("failed to encode translation", sl.Error(err))
+		return
+	}
+}
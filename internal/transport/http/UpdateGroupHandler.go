@@ -0,0 +1,83 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+	"github.com/notblinkyet/song-library-api/internal/lib/validation"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// @Summary Rename a group by ID
+// @Description Renames an existing group. All songs of the group keep referring to it.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param id path int true "Group ID"
+// @Param group body models.GroupRequest true "New group name"
+// @Success 200 {object} models.Group "Successfully renamed group"
+// @Failure 400 {object} models.Problem "Invalid request (e.g., invalid JSON or missing name)"
+// @Failure 404 {object} models.Problem "Group not found"
+// @Failure 409 {object} models.Problem "Group with this name already exists"
+// @Failure 500 {object} models.Problem "Internal server error"
+// @Router /groups/{id} [patch]
+func (h *Handler) UpdateGroup(w http.ResponseWriter, r *http.Request) {
+	h.log.Info("received request to update a group")
+
+	// Parse the group ID from the URL parameter.
+	idString := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		h.log.Error("failed to parse group ID", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid group ID")
+		return
+	}
+
+	// Parse and decode the new group name from the request body.
+	var req models.GroupRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		h.log.Error("failed to decode request body", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "invalid request body")
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	var v validation.Validator
+	validation.GroupRequest(&v, &req)
+	if err = v.Err(); err != nil {
+		h.log.Warn("invalid group", sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+
+	// Save the new group name.
+	err = h.service.UpdateGroup(r.Context(), &models.Group{ID: id, Name: req.Name})
+	if err != nil {
+		h.log.Error("failed to update group", slog.Int("id", id), sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+
+	// Read the group back, so the response contains the actual song count.
+	group, err := h.service.ReadGroupByID(r.Context(), id)
+	if err != nil {
+		h.log.Error("failed to retrieve group", slog.Int("id", id), sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+	h.log.Info("group updated successfully", slog.Int("id", id))
+
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent(" ", "\t")
+	err = encoder.Encode(group)
+	if err != nil {
+		h.log.Error("failed to encode group", sl.Error(err))
+		return
+	}
+}
@@ -2,88 +2,172 @@ package http
 
 import (
 	"encoding/json"
+	"io"
 	"log/slog"
+	"mime"
 	"net/http"
 	"strconv"
-	"time"
 
 	"github.com/go-chi/chi"
+	"github.com/notblinkyet/song-library-api/internal/database"
+	"github.com/notblinkyet/song-library-api/internal/lib/patch"
 	"github.com/notblinkyet/song-library-api/internal/lib/sl"
+	"github.com/notblinkyet/song-library-api/internal/lib/validation"
 	"github.com/notblinkyet/song-library-api/internal/models"
 )
 
+// Media types of the PATCH /songs/{id} request body.
+const (
+	mediaTypeJSON       = "application/json"
+	mediaTypeMergePatch = "application/merge-patch+json"
+	mediaTypeJSONPatch  = "application/json-patch+json"
+)
+
 // @Summary Update a song by ID
-// @Description Updates an existing song in the library by its ID. Only the specified fields in the request body will be updated. The request body must contain a valid JSON representation of the `models.Song` struct.
+// @Description Partially updates an existing song in the library by its ID.
+// @Description With Content-Type application/merge-patch+json (or application/json) the body is an RFC 7396 JSON Merge Patch: only the fields present in it change, and null clears a field (text, link, releaseDate). song and group can't be cleared.
+// @Description With Content-Type application/json-patch+json the body is an RFC 6902 JSON Patch, e.g. [{"op": "replace", "path": "/link", "value": ""}].
 // @Tags songs
 // @Accept json
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
 // @Produce json
 // @Param id path int true "Song ID"
-// @Param song body models.Song true "Updated song information. Only fields with non-empty values will be updated."
+// @Param If-Match header string false "ETag of the song version the changes are based on"
+// @Param song body models.SongRequest true "Merge patch or JSON patch of the song fields"
 // @Success 200 {object} models.Song "Successfully updated song"
-// @Failure 400 {object} string "Invalid request (e.g., invalid JSON or missing required fields)."
-// @Failure 404 {object} string "Song not found."
-// @Failure 500 {object} string "Internal server error during the update process."
-// @Router /songs/{id} [patch]]
+// @Header 200 {string} ETag "New version of the song"
+// @Failure 400 {object} models.Problem "Invalid request (e.g., invalid JSON or missing required fields)."
+// @Failure 404 {object} models.Problem "Song not found."
+// @Failure 409 {object} models.Problem "JSON patch can't be applied to the song (failed test or missing path)."
+// @Failure 412 {object} models.Problem "Song has been modified since the version in If-Match."
+// @Failure 415 {object} models.Problem "Unsupported Content-Type."
+// @Failure 500 {object} models.Problem "Internal server error during the update process."
+// @Router /songs/{id} [patch]
 func (h *Handler) UpdateSong(w http.ResponseWriter, r *http.Request) {
 	h.log.Info("received request to update a song")
 
-	// Parse the song ID from the URL parameter.
-	idString := chi.URLParam(r, "id")
-	id, err := strconv.Atoi(idString)
-	if err != nil {
-		h.log.Error("failed to parse song ID", sl.Error(err))
-		http.Error(w, "Invalid song ID", http.StatusBadRequest)
+	// Pick the patch format from the Content-Type.
+	var apply func(doc, patch []byte) ([]byte, error)
+	mediaType := mediaTypeJSON
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		var err error
+		mediaType, _, err = mime.ParseMediaType(contentType)
+		if err != nil {
+			h.log.Warn("failed to parse Content-Type", sl.Error(err))
+			writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "invalid Content-Type")
+			return
+		}
+	}
+	switch mediaType {
+	case mediaTypeJSON, mediaTypeMergePatch:
+		apply = patch.MergePatch
+	case mediaTypeJSONPatch:
+		apply = patch.JSONPatch
+	default:
+		h.log.Warn("unsupported Content-Type", slog.String("contentType", mediaType))
+		w.Header().Set("Accept-Patch", mediaTypeMergePatch+", "+mediaTypeJSONPatch)
+		writeProblem(w, r, http.StatusUnsupportedMediaType, CodeUnsupportedMediaType, "unsupported Content-Type "+mediaType)
 		return
 	}
 
-	// Retrieve the current version of the song from the service.
-	song, err := h.service.ReadByID(id)
+	song, ok := h.readSongForUpdate(w, r)
+	if !ok {
+		return
+	}
+
+	// Read the patch from the request body.
+	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		h.log.Error("failed to retrieve song", slog.Int("id", id), sl.Error(err))
-		http.Error(w, "Song not found", http.StatusNotFound)
+		h.log.Error("failed to read request body", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeInvalidRequest, "invalid request body")
 		return
 	}
 
-	// Parse and decode the updated song data from the request body.
-	var newInfo models.Song
-	err = json.NewDecoder(r.Body).Decode(&newInfo)
+	// Apply the patch to the editable fields of the song.
+	doc, err := json.Marshal(models.NewSongRequest(song))
 	if err != nil {
-		h.log.Error("failed to decode request body", sl.Error(err))
+		h.log.Error("failed to encode song", sl.Error(err))
+		writeError(w, r, err)
+		return
+	}
+	doc, err = apply(doc, body)
+	if err != nil {
+		h.log.Warn("failed to apply patch", slog.Int("id", song.ID), sl.Error(err))
+		writeError(w, r, err)
 		return
 	}
 
-	// Update the song fields with the new data.
-	if newInfo.Title != "" {
-		song.Title = newInfo.Title
+	var req models.SongRequest
+	err = json.Unmarshal(doc, &req)
+	if err != nil {
+		h.log.Warn("patched song is invalid", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid song fields")
+		return
 	}
-	if newInfo.Group != "" {
-		song.Group = newInfo.Group
+	var v validation.Validator
+	validation.SongRequest(&v, &req)
+	if err = v.Err(); err != nil {
+		h.log.Warn("invalid song", sl.Error(err))
+		writeError(w, r, err)
+		return
 	}
-	if newInfo.ReleaseDate.Equal(time.Time{}) {
-		song.ReleaseDate = newInfo.ReleaseDate
+	req.Apply(song)
+	h.log.Debug("updated song fields", slog.Any("song", song))
+
+	h.saveSong(w, r, song)
+}
+
+// readSongForUpdate reads the song from the id URL parameter and checks the
+// If-Match header against its version. On failure it writes the error
+// response and returns false.
+func (h *Handler) readSongForUpdate(w http.ResponseWriter, r *http.Request) (*models.Song, bool) {
+	// Parse the song ID from the URL parameter.
+	idString := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		h.log.Error("failed to parse song ID", sl.Error(err))
+		writeProblem(w, r, http.StatusBadRequest, CodeValidationFailed, "invalid song ID")
+		return nil, false
 	}
-	if newInfo.Text != "" {
-		song.Text = newInfo.Text
+
+	// Retrieve the current version of the song from the service.
+	song, err := h.service.ReadByID(r.Context(), id)
+	if err != nil {
+		h.log.Error("failed to retrieve song", slog.Int("id", id), sl.Error(err))
+		writeError(w, r, err)
+		return nil, false
 	}
-	if newInfo.Link != "" {
-		song.Link = newInfo.Link
+
+	// Refuse to apply changes based on an outdated version of the song.
+	if match := r.Header.Get("If-Match"); match != "" && !matchETag(match, song.Version, false) {
+		h.log.Warn("song version doesn't match If-Match", slog.Int("id", id), slog.Int("version", song.Version))
+		writeError(w, r, database.ErrVersionMismatch)
+		return nil, false
 	}
-	h.log.Debug("updated song fields", slog.Any("song", song))
+	return song, true
+}
 
-	// Save the updated song data.
-	err = h.service.UpdateSong(song)
+// saveSong stores the changed song and writes it to the response. The storage
+// only applies the update if the song still has the version it was read with,
+// so concurrent edits aren't lost.
+func (h *Handler) saveSong(w http.ResponseWriter, r *http.Request, song *models.Song) {
+	err := h.service.UpdateSong(r.Context(), song)
 	if err != nil {
-		h.log.Error("failed to update song", slog.Int("id", id), sl.Error(err))
-		http.Error(w, "Failed to update song", http.StatusInternalServerError)
+		h.log.Error("failed to update song", slog.Int("id", song.ID), sl.Error(err))
+		writeError(w, r, err)
 		return
 	}
-	h.log.Info("song updated successfully", slog.Int("id", id))
+	h.log.Info("song updated successfully", slog.Int("id", song.ID))
+
+	// Return the updated song in the response.
+	w.Header().Set("ETag", etag(song.Version))
 	w.WriteHeader(http.StatusOK)
 	encoder := json.NewEncoder(w)
 	encoder.SetIndent(" ", "\t")
-	err = encoder.Encode(&song)
+	err = encoder.Encode(song)
 	if err != nil {
-		h.log.Error("failed to decode request body", sl.Error(err))
+		h.log.Error("failed to encode song", sl.Error(err))
 		return
 	}
 }
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"strconv"
+	"strings"
+)
+
+// etag formats the version of a song as a strong entity tag.
+func etag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// translationETag formats the versions of a song and of its translation into
+// the language as a strong entity tag, so that a response changes with both.
+func translationETag(version int, lang string, translationVersion int) string {
+	return `"` + strconv.Itoa(version) + "-" + lang + "-" + strconv.Itoa(translationVersion) + `"`
+}
+
+// matchETag reports whether an If-Match or If-None-Match header value lists
+// the entity tag of the version. "*" matches any version. If-Match requires
+// strong comparison, so weak tags only match when weak is set.
+func matchETag(header string, version int, weak bool) bool {
+	return matchTag(header, etag(version), weak)
+}
+
+// matchTag is matchETag for any entity tag.
+func matchTag(header, want string, weak bool) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+		if strings.HasPrefix(tag, "W/") {
+			if !weak {
+				continue
+			}
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+		if tag == want {
+			return true
+		}
+	}
+	return false
+}
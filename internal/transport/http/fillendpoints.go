@@ -4,16 +4,53 @@ import (
 	"net/http"
 
 	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
 func (h *Handler) FillEndpoints(r *chi.Mux) {
+	// Every request gets an ID that is returned with error responses.
+	r.Use(middleware.RequestID)
+	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		writeProblem(w, r, http.StatusNotFound, CodeNotFound, "no such endpoint")
+	})
+	r.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		writeProblem(w, r, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method is not allowed for this endpoint")
+	})
 
 	r.Post("/songs", h.CreateSong)
 	r.Get("/songs", h.ReadFilteredSongs)
 	r.Get("/songs/{id}", h.ReadVerse)
 	r.Patch("/songs/{id}", h.UpdateSong)
+	r.Put("/songs/{id}", h.ReplaceSong)
 	r.Delete("/songs/{id}", h.DeleteSong)
+	r.Post("/songs/{id}/restore", h.RestoreSong)
+	r.Post("/songs/{id}/refresh", h.RefreshSong)
+	r.Get("/songs/{id}/sections", h.ReadSections)
+	r.Get("/songs/{id}/lyrics", h.ReadLyrics)
+	r.Get("/songs/{id}/lyrics/synced", h.ReadSyncedLyrics)
+	r.Put("/songs/{id}/lyrics/synced", h.SaveSyncedLyrics)
+	r.Delete("/songs/{id}/lyrics/synced", h.DeleteSyncedLyrics)
+	r.Get("/songs/{id}/lyrics/at", h.ReadLyricsAt)
+	r.Get("/songs/{id}/chords", h.ReadChordSheet)
+	r.Put("/songs/{id}/chords", h.SaveChordChart)
+	r.Delete("/songs/{id}/chords", h.DeleteChordChart)
+	r.Get("/songs/{id}/translations", h.ReadTranslations)
+	r.Get("/songs/{id}/translations/{lang}", h.ReadTranslation)
+	r.Put("/songs/{id}/translations/{lang}", h.SaveTranslation)
+	r.Delete("/songs/{id}/translations/{lang}", h.DeleteTranslation)
+	r.Get("/trash", h.ReadTrash)
+	r.Get("/songs/{id}/versions", h.ReadSongVersions)
+	r.Get("/songs/{id}/versions/{version}", h.ReadSongVersion)
+	r.Post("/songs/{id}/versions/{version}/restore", h.RestoreSongVersion)
+	r.Post("/groups", h.CreateGroup)
+	r.Get("/groups", h.ReadGroups)
+	r.Get("/groups/{id}", h.ReadGroup)
+	r.Patch("/groups/{id}", h.UpdateGroup)
+	r.Delete("/groups/{id}", h.DeleteGroup)
+	r.Get("/groups/{id}/songs", h.ReadGroupSongs)
+	r.Get("/jobs/{id}", h.ReadJob)
+	r.Get("/status/upstream", h.ReadUpstreamStatus)
 	r.Get("/swagger/doc.json", func(w http.ResponseWriter, r *http.Request) {
 		http.ServeFile(w, r, "./docs/swagger.json")
 	})
@@ -0,0 +1,31 @@
+package http
+
+import (
+	"net/http"
+
+	parseurl "github.com/notblinkyet/song-library-api/internal/lib/ParseURL"
+	"github.com/notblinkyet/song-library-api/internal/lib/langtag"
+	"github.com/notblinkyet/song-library-api/internal/lib/validation"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+// parseLanguage extracts the lang query parameter and the Accept-Language
+// header shared by the lyrics endpoints. A malformed lang is recorded in v.
+func parseLanguage(r *http.Request, v *validation.Validator) models.LanguageQuery {
+	query := models.LanguageQuery{AcceptLanguage: r.Header.Get("Accept-Language")}
+	if lang := parseurl.ParseString(r.URL.Query(), "lang", ""); lang != "" {
+		var err error
+		query.Lang, err = langtag.Canonical(lang)
+		v.AddError("lang", err)
+	}
+	return query
+}
+
+// setLanguage describes the language of a lyrics response. The response
+// depends on Accept-Language, and lang is empty for the original text.
+func setLanguage(w http.ResponseWriter, lang string) {
+	w.Header().Add("Vary", "Accept-Language")
+	if lang != "" {
+		w.Header().Set("Content-Language", lang)
+	}
+}
@@ -0,0 +1,122 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	parseurl "github.com/notblinkyet/song-library-api/internal/lib/ParseURL"
+	"github.com/notblinkyet/song-library-api/internal/lib/cursor"
+	"github.com/notblinkyet/song-library-api/internal/lib/validation"
+	"github.com/notblinkyet/song-library-api/internal/models"
+)
+
+var (
+	ErrInvalidSort = errors.New("invalid sort")
+)
+
+// parsePagination extracts the limit, offset, cursor, sort and total query
+// parameters shared by the song listings. Malformed values are recorded in v.
+func parsePagination(values url.Values, filter *models.Filter, v *validation.Validator) {
+	var err error
+	filter.Limit, err = parseurl.ParseInt(values, "limit", 0)
+	v.AddError("limit", err)
+	filter.Offset, err = parseurl.ParseInt(values, "offset", 0)
+	v.AddError("offset", err)
+	filter.WithTotal, err = parseurl.ParseBool(values, "total", false)
+	v.AddError("total", err)
+
+	filter.Sort, err = parseSort(parseurl.ParseString(values, "sort", ""))
+	v.AddError("sort", err)
+
+	// Cursor pagination replaces the offset.
+	if token := parseurl.ParseString(values, "cursor", ""); token != "" {
+		c, err := cursor.Decode(token)
+		if err != nil {
+			v.AddError("cursor", cursor.ErrInvalidCursor)
+			return
+		}
+		filter.Cursor = c
+		filter.Offset = 0
+	}
+}
+
+// sortFields whitelists the keys accepted by the sort query parameter.
+var sortFields = map[string]string{
+	"id":           models.SortID,
+	"title":        models.SortTitle,
+	"song":         models.SortTitle,
+	"group":        models.SortGroup,
+	"release_date": models.SortReleaseDate,
+}
+
+// parseSort parses a sort expression like "-release_date,title".
+func parseSort(value string) ([]models.SortField, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	var sort []models.SortField
+	seen := make(map[string]bool)
+	for _, key := range strings.Split(value, ",") {
+		key = strings.TrimSpace(key)
+		desc := strings.HasPrefix(key, "-")
+		key = strings.TrimPrefix(strings.TrimPrefix(key, "-"), "+")
+
+		field, ok := sortFields[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidSort, key)
+		}
+		if seen[field] {
+			return nil, fmt.Errorf("%w: duplicate field %q", ErrInvalidSort, key)
+		}
+		seen[field] = true
+		sort = append(sort, models.SortField{Field: field, Desc: desc})
+	}
+	return sort, nil
+}
+
+// paginationLinks builds an RFC 8288 Link header value with the first, next
+// and previous pages of a listing. The links keep all other query parameters
+// of the original request.
+func paginationLinks(r *http.Request, filter *models.Filter, page *models.SongPage) string {
+	links := make([]string, 0, 3)
+
+	link := func(rel string, change func(values url.Values)) {
+		values := r.URL.Query()
+		values.Del("cursor")
+		values.Del("offset")
+		change(values)
+		u := *r.URL
+		u.RawQuery = values.Encode()
+		links = append(links, fmt.Sprintf("<%s>; rel=%q", u.RequestURI(), rel))
+	}
+
+	link("first", func(url.Values) {})
+
+	if page.HasMore {
+		switch {
+		case page.NextCursor != "":
+			link("next", func(values url.Values) {
+				values.Set("cursor", page.NextCursor)
+			})
+		case filter.Limit > 0:
+			link("next", func(values url.Values) {
+				values.Set("offset", strconv.Itoa(filter.Offset+filter.Limit))
+			})
+		}
+	}
+
+	if filter.Cursor == nil && filter.Offset > 0 && filter.Limit > 0 {
+		link("prev", func(values url.Values) {
+			if prev := filter.Offset - filter.Limit; prev > 0 {
+				values.Set("offset", strconv.Itoa(prev))
+			}
+		})
+	}
+
+	return strings.Join(links, ", ")
+}
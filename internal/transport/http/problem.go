@@ -0,0 +1,129 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/notblinkyet/song-library-api/internal/database"
+	"github.com/notblinkyet/song-library-api/internal/lib/api"
+	"github.com/notblinkyet/song-library-api/internal/lib/chordpro"
+	"github.com/notblinkyet/song-library-api/internal/lib/cursor"
+	"github.com/notblinkyet/song-library-api/internal/lib/patch"
+	"github.com/notblinkyet/song-library-api/internal/lib/synced"
+	"github.com/notblinkyet/song-library-api/internal/lib/validation"
+	"github.com/notblinkyet/song-library-api/internal/models"
+	"github.com/notblinkyet/song-library-api/internal/services"
+)
+
+// Machine-readable codes of error responses. They are part of the API and
+// must not change.
+const (
+	CodeInvalidRequest       = "invalid_request"
+	CodeValidationFailed     = "validation_failed"
+	CodeNotFound             = "not_found"
+	CodeAlreadyExists        = "already_exists"
+	CodeGroupNotEmpty        = "group_not_empty"
+	CodeVersionMismatch      = "version_mismatch"
+	CodeVerseOutOfBound      = "verse_out_of_bound"
+	CodeLineOutOfBound       = "line_out_of_bound"
+	CodeInvalidSyncedLyrics  = "invalid_synced_lyrics"
+	CodeInvalidChordChart    = "invalid_chord_chart"
+	CodeMisalignedVerses     = "misaligned_verses"
+	CodeInvalidCursor        = "invalid_cursor"
+	CodePatchConflict        = "patch_conflict"
+	CodeMethodNotAllowed     = "method_not_allowed"
+	CodeUnsupportedMediaType = "unsupported_media_type"
+	CodeSongInfoRejected     = "song_info_rejected"
+	CodeUpstreamFailed       = "upstream_failed"
+	CodeUpstreamUnavailable  = "upstream_unavailable"
+	CodeInternal             = "internal_error"
+)
+
+const mediaTypeProblem = "application/problem+json"
+
+// knownErrors maps errors of the lower layers to the status and code of the
+// response. The message of the known error is used as the problem detail;
+// errors marked with wrapped describe the request itself, so the whole
+// message of the returned error is used instead.
+var knownErrors = []struct {
+	err     error
+	status  int
+	code    string
+	wrapped bool
+}{
+	{database.ErrNotFound, http.StatusNotFound, CodeNotFound, false},
+	{database.ErrAlreadyExists, http.StatusConflict, CodeAlreadyExists, false},
+	{database.ErrGroupNotEmpty, http.StatusConflict, CodeGroupNotEmpty, false},
+	{database.ErrVersionMismatch, http.StatusPreconditionFailed, CodeVersionMismatch, false},
+	{services.ErrVerseOutOfBound, http.StatusBadRequest, CodeVerseOutOfBound, false},
+	{services.ErrLineOutOfBound, http.StatusBadRequest, CodeLineOutOfBound, false},
+	{services.ErrTranslationMisaligned, http.StatusBadRequest, CodeMisalignedVerses, true},
+	{services.ErrCursorWithSearch, http.StatusBadRequest, CodeInvalidCursor, false},
+	{synced.ErrInvalid, http.StatusBadRequest, CodeInvalidSyncedLyrics, true},
+	{chordpro.ErrInvalid, http.StatusBadRequest, CodeInvalidChordChart, true},
+	{cursor.ErrInvalidCursor, http.StatusBadRequest, CodeInvalidCursor, false},
+	{patch.ErrInvalidPatch, http.StatusBadRequest, CodeInvalidRequest, true},
+	{patch.ErrTestFailed, http.StatusConflict, CodePatchConflict, true},
+	{patch.ErrPathNotFound, http.StatusConflict, CodePatchConflict, true},
+	{api.ErrBadRequest, http.StatusBadRequest, CodeSongInfoRejected, false},
+	{api.ErrInternalServer, http.StatusBadGateway, CodeUpstreamFailed, false},
+	{api.ErrUnreachable, http.StatusBadGateway, CodeUpstreamFailed, false},
+	{api.ErrInvalidResponse, http.StatusBadGateway, CodeUpstreamFailed, false},
+	{api.ErrCircuitOpen, http.StatusServiceUnavailable, CodeUpstreamUnavailable, false},
+}
+
+// writeError writes the problem response for an error returned by the
+// service. Unknown errors are reported as internal without any details.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var fields validation.Errors
+	if errors.As(err, &fields) {
+		problem := newProblem(r, http.StatusBadRequest, CodeValidationFailed, "request has invalid fields")
+		problem.Errors = fields
+		sendProblem(w, problem)
+		return
+	}
+
+	for _, known := range knownErrors {
+		if errors.Is(err, known.err) {
+			detail := known.err.Error()
+			if known.wrapped {
+				detail = err.Error()
+			}
+			writeProblem(w, r, known.status, known.code, detail)
+			return
+		}
+	}
+	writeProblem(w, r, http.StatusInternalServerError, CodeInternal, "internal server error")
+}
+
+// writeProblem writes an RFC 7807 problem response.
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, code, detail string) {
+	sendProblem(w, newProblem(r, status, code, detail))
+}
+
+// newProblem describes an error of the request, including the request ID
+// assigned by the RequestID middleware.
+func newProblem(r *http.Request, status int, code, detail string) *models.Problem {
+	return &models.Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Instance:  r.URL.Path,
+		Code:      code,
+		RequestID: middleware.GetReqID(r.Context()),
+	}
+}
+
+// sendProblem writes the problem as an application/problem+json response.
+func sendProblem(w http.ResponseWriter, problem *models.Problem) {
+	w.Header().Set("Content-Type", mediaTypeProblem)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent(" ", "\t")
+	// The status is already sent, so an encoding error can't be reported.
+	_ = encoder.Encode(problem)
+}
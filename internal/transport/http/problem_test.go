@@ -0,0 +1,194 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/notblinkyet/song-library-api/internal/database"
+	"github.com/notblinkyet/song-library-api/internal/database/memory"
+	"github.com/notblinkyet/song-library-api/internal/lib/cursor"
+	"github.com/notblinkyet/song-library-api/internal/lib/validation"
+	"github.com/notblinkyet/song-library-api/internal/models"
+	"github.com/notblinkyet/song-library-api/internal/services"
+)
+
+// decodeProblem checks that the response is a problem and decodes it.
+func decodeProblem(t *testing.T, resp *httptest.ResponseRecorder) *models.Problem {
+	t.Helper()
+	if ct := resp.Header().Get("Content-Type"); ct != mediaTypeProblem {
+		t.Fatalf("Content-Type = %q, want %q", ct, mediaTypeProblem)
+	}
+	var problem models.Problem
+	if err := json.NewDecoder(resp.Body).Decode(&problem); err != nil {
+		t.Fatalf("failed to decode problem: %v", err)
+	}
+	if problem.Status != resp.Code {
+		t.Errorf("status in the body = %d, response status %d", problem.Status, resp.Code)
+	}
+	return &problem
+}
+
+func TestWriteError(t *testing.T) {
+	var v validation.Validator
+	v.Add("song", "is required")
+	v.Add("limit", "must not be negative")
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+		wantFields []models.FieldError
+	}{
+		{
+			name:       "validation errors",
+			err:        fmt.Errorf("handler: %w", v.Err()),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   CodeValidationFailed,
+			wantDetail: "request has invalid fields",
+			wantFields: []models.FieldError{{Field: "song", Message: "is required"}, {Field: "limit", Message: "must not be negative"}},
+		},
+		{
+			name:       "wrapped known error",
+			err:        fmt.Errorf("postgresql.ReadByID: %w", database.ErrNotFound),
+			wantStatus: http.StatusNotFound,
+			wantCode:   CodeNotFound,
+			wantDetail: database.ErrNotFound.Error(),
+		},
+		{
+			name:       "invalid cursor",
+			err:        cursor.ErrInvalidCursor,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   CodeInvalidCursor,
+			wantDetail: cursor.ErrInvalidCursor.Error(),
+		},
+		{
+			name:       "version mismatch",
+			err:        database.ErrVersionMismatch,
+			wantStatus: http.StatusPreconditionFailed,
+			wantCode:   CodeVersionMismatch,
+			wantDetail: database.ErrVersionMismatch.Error(),
+		},
+		{
+			name:       "unknown error hides details",
+			err:        errors.New("connection refused by 10.0.0.1"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   CodeInternal,
+			wantDetail: "internal server error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			writeError(resp, httptest.NewRequest(http.MethodGet, "/songs/1", nil), tt.err)
+
+			if resp.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.Code, tt.wantStatus)
+			}
+			problem := decodeProblem(t, resp)
+			if problem.Code != tt.wantCode || problem.Detail != tt.wantDetail || problem.Instance != "/songs/1" {
+				t.Errorf("problem = %+v", problem)
+			}
+			if !reflect.DeepEqual(problem.Errors, tt.wantFields) {
+				t.Errorf("errors = %+v, want %+v", problem.Errors, tt.wantFields)
+			}
+		})
+	}
+}
+
+// newTestRouter serves the API over in-memory storage holding the songs.
+func newTestRouter(t *testing.T, titles ...string) http.Handler {
+	t.Helper()
+	db := memory.NewMemory()
+	t.Cleanup(db.Close)
+	for _, title := range titles {
+		if _, err := db.CreateSong(context.Background(), &models.Song{Title: title, Group: "Muse"}); err != nil {
+			t.Fatalf("failed to create song: %v", err)
+		}
+	}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	r := chi.NewMux()
+	NewHandler(services.NewSongLibraryService(db, nil, log), log, false).FillEndpoints(r)
+	return r
+}
+
+func TestCursorPagination(t *testing.T) {
+	router := newTestRouter(t, "Uprising", "Starlight", "Hysteria")
+	get := func(query url.Values) *httptest.ResponseRecorder {
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/songs?"+query.Encode(), nil))
+		return resp
+	}
+
+	// Follow the cursors through the songs sorted by title.
+	var titles []string
+	var lastCursor string
+	query := url.Values{"limit": {"2"}, "sort": {"title"}}
+	for page := 0; ; page++ {
+		if page == 3 {
+			t.Fatalf("cursors don't end")
+		}
+		resp := get(query)
+		if resp.Code != http.StatusOK {
+			t.Fatalf("page %d: status = %d, body %s", page, resp.Code, resp.Body)
+		}
+		var songs models.SongPage
+		if err := json.NewDecoder(resp.Body).Decode(&songs); err != nil {
+			t.Fatalf("page %d: failed to decode: %v", page, err)
+		}
+		for _, song := range songs.Items {
+			titles = append(titles, song.Title)
+		}
+		if songs.NextCursor == "" {
+			break
+		}
+		lastCursor = songs.NextCursor
+		query.Set("cursor", songs.NextCursor)
+	}
+	if want := []string{"Hysteria", "Starlight", "Uprising"}; !reflect.DeepEqual(titles, want) {
+		t.Errorf("titles = %q, want %q", titles, want)
+	}
+
+	tests := []struct {
+		name      string
+		query     url.Values
+		wantCode  string
+		wantField string
+	}{
+		{name: "malformed cursor", query: url.Values{"cursor": {"not a cursor!"}}, wantCode: CodeValidationFailed, wantField: "cursor"},
+		{name: "tampered cursor", query: url.Values{"cursor": {"X" + lastCursor[1:]}, "sort": {"title"}}, wantCode: CodeValidationFailed, wantField: "cursor"},
+		{name: "cursor of another sort", query: url.Values{"cursor": {lastCursor}, "sort": {"-title"}}, wantCode: CodeInvalidCursor},
+		{name: "cursor without its sort", query: url.Values{"cursor": {lastCursor}}, wantCode: CodeInvalidCursor},
+		{
+			name:     "cursor with search",
+			query:    url.Values{"cursor": {cursor.Encode(&models.Cursor{ID: 1})}, "q": {"uprising"}},
+			wantCode: CodeInvalidCursor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := get(tt.query)
+			if resp.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d", resp.Code, http.StatusBadRequest)
+			}
+			problem := decodeProblem(t, resp)
+			if problem.Code != tt.wantCode {
+				t.Errorf("code = %q, want %q", problem.Code, tt.wantCode)
+			}
+			if tt.wantField != "" && (len(problem.Errors) != 1 || problem.Errors[0].Field != tt.wantField) {
+				t.Errorf("errors = %+v, want one for %q", problem.Errors, tt.wantField)
+			}
+		})
+	}
+}
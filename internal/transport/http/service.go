@@ -1,14 +1,44 @@
 package http
 
 import (
+	"context"
+
 	"github.com/notblinkyet/song-library-api/internal/models"
 )
 
 type SongLibraryService interface {
-	Create(req *models.CreateSongRequest) (int, error)
-	ReadFilteredSongs(filter *models.Filter) ([]models.Song, error)
-	ReadVerse(id, start, count int) ([]*models.Verse, error)
-	UpdateSong(song *models.Song) error
-	DeleteSong(id int) error
-	ReadByID(id int) (*models.Song, error)
+	Create(ctx context.Context, req *models.CreateSongRequest) (int, error)
+	CreateAsync(ctx context.Context, req *models.CreateSongRequest) (*models.Job, error)
+	ReadJob(ctx context.Context, id int) (*models.Job, error)
+	ReadFilteredSongs(ctx context.Context, filter *models.Filter) (*models.SongPage, error)
+	ReadVerse(ctx context.Context, id, start, count int, lang *models.LanguageQuery) (*models.SongVerses, error)
+	ReadSections(ctx context.Context, id int) (*models.SongSections, error)
+	ReadLyrics(ctx context.Context, id int, query *models.LyricsQuery) (*models.Lyrics, error)
+	ReadSyncedLyrics(ctx context.Context, id int) (*models.SyncedLyrics, error)
+	SaveSyncedLyrics(ctx context.Context, id int, lines []models.SyncedLine) (*models.SyncedLyrics, error)
+	DeleteSyncedLyrics(ctx context.Context, id int) error
+	LyricsAt(ctx context.Context, id int, t float64) (*models.LyricsPosition, error)
+	ReadChordSheet(ctx context.Context, id, transpose int, capo *int) (*models.ChordSheet, error)
+	SaveChordChart(ctx context.Context, id int, source string) (*models.ChordChart, error)
+	DeleteChordChart(ctx context.Context, id int) error
+	ReadTranslations(ctx context.Context, id int) ([]models.Translation, error)
+	ReadTranslation(ctx context.Context, id int, lang string) (*models.Translation, error)
+	SaveTranslation(ctx context.Context, id int, lang, text string) (*models.Translation, bool, error)
+	DeleteTranslation(ctx context.Context, id int, lang string) error
+	UpdateSong(ctx context.Context, song *models.Song) error
+	DeleteSong(ctx context.Context, id, version int) error
+	ReadByID(ctx context.Context, id int) (*models.Song, error)
+	ReadTrash(ctx context.Context, filter *models.Filter) (*models.SongPage, error)
+	RestoreSong(ctx context.Context, id int) (*models.Song, error)
+	RefreshSong(ctx context.Context, id int, force, dryRun bool) (*models.SongRefresh, error)
+	ReadSongVersions(ctx context.Context, id int) ([]models.SongVersion, error)
+	ReadSongVersion(ctx context.Context, id, version int) (*models.SongVersion, error)
+	RestoreSongVersion(ctx context.Context, id, version int) (*models.Song, error)
+	UpstreamStatus() []models.UpstreamStatus
+	ReadGroups(ctx context.Context) ([]models.Group, error)
+	ReadGroupByID(ctx context.Context, id int) (*models.Group, error)
+	CreateGroup(ctx context.Context, name string) (int, error)
+	UpdateGroup(ctx context.Context, group *models.Group) error
+	DeleteGroup(ctx context.Context, id int) error
+	ReadGroupSongs(ctx context.Context, id int, filter *models.Filter) ([]models.Song, error)
 }